@@ -3,21 +3,97 @@ package keycloak
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"sync"
+	"time"
 )
 
 // An authenticated Keycloak API client
 type KeycloakClient struct {
-	token string
-	url   string
+	// The URL of the Keycloak server including its base path, which all API URIs are relative to.
+	url        string
+	realm      string
+	httpClient *http.Client
+
+	baseUrl            string
+	basePath           string
+	basePathConfigured bool
+
+	clientId     string
+	clientSecret string
+
+	// Set when logging in with the resource owner password grant instead of client credentials.
+	username string
+	password string
+
+	// Set when the access token is read from a file instead of being requested from Keycloak.
+	tokenFile string
+
+	// Token state is shared between all resources, which Terraform manages concurrently.
+	tokenLock          sync.Mutex
+	token              string
+	tokenExpiry        time.Time
+	refreshToken       string
+	refreshTokenExpiry time.Time
+
+	retryPolicy RetryPolicy
 }
 
-// A function that mimics the default HTTP client 'Do' but authenticates all requests.
+// A function that mimics the default HTTP client 'Do' but authenticates all requests and
+// retries them according to the client's retry policy if they fail with a transient error.
 func (c *KeycloakClient) do(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	return http.DefaultClient.Do(req)
+	return c.retry(req, c.doAuthenticated)
+}
+
+// Access tokens are renewed shortly before they expire and a request that is rejected
+// with a 401 is retried once with a freshly acquired token.
+func (c *KeycloakClient) doAuthenticated(req *http.Request) (*http.Response, error) {
+	token, err := c.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token may have been invalidated before its expiry (for example by a Keycloak restart
+	// or a revoked session), in which case a new login is attempted and the request is retried.
+	resp.Body.Close()
+	log.Printf("[DEBUG] Keycloak rejected access token for %s %s, logging in again", req.Method, req.URL)
+
+	token, err = c.renewRejectedToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	err = rewindBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return c.httpClient.Do(req)
+}
+
+// Resets the body of a request so that it can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+	return nil
 }
 
 // Attempt to perform a GET request to the specified URL (with authentication).
@@ -35,7 +111,7 @@ func (c *KeycloakClient) get(url string, v interface{}) error {
 	body, _ := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return fmt.Errorf("Could not get %s: %s (%d)", url, string(body), resp.StatusCode)
+		return newApiError(resp, body)
 	}
 
 	err = json.Unmarshal(body, v)
@@ -56,6 +132,31 @@ func (c *KeycloakClient) post(url string, v interface{}) (string, error) {
 		return "", err
 	}
 
+	return c.sendPost(req)
+}
+
+// Creates a resource that Keycloak identifies by a unique name and returns its location. Unlike post, the
+// request is also retried after failures that may have been processed by Keycloak. If a retry conflicts
+// with an existing resource, that resource was created by an earlier attempt and locate is used to look
+// up its location.
+func (c *KeycloakClient) create(url string, v interface{}, locate func() (string, error)) (string, error) {
+	reqBody, _ := json.Marshal(v)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return "", err
+	}
+
+	location, err := c.sendPost(asCreateRequest(req))
+	if err == errCreatedByEarlierAttempt {
+		log.Printf("[DEBUG] POST %s conflicted after a failed attempt, looking up the created resource", url)
+		return locate()
+	}
+
+	return location, err
+}
+
+func (c *KeycloakClient) sendPost(req *http.Request) (string, error) {
 	req.Header.Set("Content-Type", "application/json")
 	resp, err := c.do(req)
 
@@ -66,12 +167,39 @@ func (c *KeycloakClient) post(url string, v interface{}) (string, error) {
 	if resp.StatusCode != 201 && resp.StatusCode != 204 {
 		defer resp.Body.Close()
 		body, _ := ioutil.ReadAll(resp.Body)
-		return "", fmt.Errorf("Could not create resource: %s (%d)", string(body), resp.StatusCode)
+		return "", newApiError(resp, body)
 	}
 
 	return resp.Header.Get("Location"), nil
 }
 
+// Sends a POST request for endpoints that perform an action and respond with a result instead of
+// creating a resource. The result is decoded into v.
+func (c *KeycloakClient) postForResult(url string, body interface{}, v interface{}) error {
+	reqBody, _ := json.Marshal(body)
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(reqBody))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(resp.Body)
+
+	if resp.StatusCode != 200 {
+		return newApiError(resp, respBody)
+	}
+
+	return json.Unmarshal(respBody, v)
+}
+
 func (c *KeycloakClient) put(url string, v interface{}) error {
 	reqBody, _ := json.Marshal(v)
 	req, _ := http.NewRequest("PUT", url, bytes.NewBuffer(reqBody))
@@ -85,7 +213,7 @@ func (c *KeycloakClient) put(url string, v interface{}) error {
 	if resp.StatusCode != 204 {
 		defer resp.Body.Close()
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("Could not update resource: %s (%d)", string(body), resp.StatusCode)
+		return newApiError(resp, body)
 	}
 
 	return nil
@@ -110,7 +238,7 @@ func (c *KeycloakClient) delete(url string, body interface{}) error {
 	if resp.StatusCode != 204 {
 		defer resp.Body.Close()
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("Could not delete resource: %s (%d)", string(body), resp.StatusCode)
+		return newApiError(resp, body)
 	}
 
 	return nil
@@ -0,0 +1,172 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Authentication flow resource as documented in the Keycloak REST API docs. Subflows are flows that are
+// not top-level and are referenced by an execution of their parent flow.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_authenticationflowrepresentation
+type AuthenticationFlow struct {
+	Id          string `json:"id,omitempty"`
+	Alias       string `json:"alias"`
+	Description string `json:"description"`
+	ProviderId  string `json:"providerId"`
+	TopLevel    bool   `json:"topLevel"`
+	BuiltIn     bool   `json:"builtIn"`
+}
+
+// An execution is a step of a flow, which runs either an authenticator or a subflow.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_authenticationexecutionrepresentation
+type AuthenticationExecution struct {
+	Id                  string `json:"id,omitempty"`
+	ParentFlow          string `json:"parentFlow"`
+	Authenticator       string `json:"authenticator,omitempty"`
+	AuthenticatorFlow   bool   `json:"authenticatorFlow"`
+	FlowId              string `json:"flowId,omitempty"`
+	AuthenticatorConfig string `json:"authenticatorConfig,omitempty"`
+	Requirement         string `json:"requirement"`
+	Priority            int    `json:"priority"`
+}
+
+// The representation of executions that is used for changing their requirement.
+type authenticationExecutionInfo struct {
+	Id          string `json:"id"`
+	Requirement string `json:"requirement"`
+}
+
+// Configuration of an execution's authenticator, e.g. the default identity provider of an IdP redirector.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_authenticatorconfigrepresentation
+type AuthenticationExecutionConfig struct {
+	Id     string            `json:"id,omitempty"`
+	Alias  string            `json:"alias"`
+	Config map[string]string `json:"config"`
+}
+
+const (
+	authenticationFlowsUri              = "%s/admin/realms/%s/authentication/flows"
+	authenticationFlowUri               = "%s/admin/realms/%s/authentication/flows/%s"
+	authenticationFlowExecutionsUri     = "%s/admin/realms/%s/authentication/flows/%s/executions"
+	authenticationExecutionsUri         = "%s/admin/realms/%s/authentication/executions"
+	authenticationExecutionUri          = "%s/admin/realms/%s/authentication/executions/%s"
+	authenticationExecutionNewConfigUri = "%s/admin/realms/%s/authentication/executions/%s/config"
+	authenticationConfigUri             = "%s/admin/realms/%s/authentication/config/%s"
+)
+
+func (c *KeycloakClient) GetAuthenticationFlow(realm string, id string) (*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, id)
+
+	var flow AuthenticationFlow
+	err := c.get(url, &flow)
+
+	return &flow, err
+}
+
+func (c *KeycloakClient) CreateAuthenticationFlow(realm string, flow *AuthenticationFlow) (*AuthenticationFlow, error) {
+	url := fmt.Sprintf(authenticationFlowsUri, c.url, realm)
+	flowLocation, err := c.create(url, *flow, func() (string, error) {
+		return c.authenticationFlowLocation(realm, flow.Alias)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var createdFlow AuthenticationFlow
+	err = c.get(flowLocation, &createdFlow)
+
+	return &createdFlow, err
+}
+
+// Looks up the location of a flow by its alias, which is unique within a realm.
+func (c *KeycloakClient) authenticationFlowLocation(realm string, alias string) (string, error) {
+	var flows []AuthenticationFlow
+	err := c.get(fmt.Sprintf(authenticationFlowsUri, c.url, realm), &flows)
+	if err != nil {
+		return "", err
+	}
+
+	for _, flow := range flows {
+		if flow.Alias == alias {
+			return fmt.Sprintf(authenticationFlowUri, c.url, realm, flow.Id), nil
+		}
+	}
+
+	return "", fmt.Errorf("authentication flow %s was not found in realm %s", alias, realm)
+}
+
+func (c *KeycloakClient) UpdateAuthenticationFlow(realm string, flow *AuthenticationFlow) error {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, flow.Id)
+	return c.put(url, *flow)
+}
+
+func (c *KeycloakClient) DeleteAuthenticationFlow(realm string, id string) error {
+	url := fmt.Sprintf(authenticationFlowUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
+
+func (c *KeycloakClient) GetAuthenticationExecution(realm string, id string) (*AuthenticationExecution, error) {
+	url := fmt.Sprintf(authenticationExecutionUri, c.url, realm, id)
+
+	var execution AuthenticationExecution
+	err := c.get(url, &execution)
+
+	return &execution, err
+}
+
+func (c *KeycloakClient) CreateAuthenticationExecution(realm string, execution *AuthenticationExecution) (*AuthenticationExecution, error) {
+	url := fmt.Sprintf(authenticationExecutionsUri, c.url, realm)
+	executionLocation, err := c.post(url, *execution)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdExecution AuthenticationExecution
+	err = c.get(executionLocation, &createdExecution)
+
+	return &createdExecution, err
+}
+
+// Keycloak only allows changing the requirement of an existing execution, through the executions of its
+// parent flow. The flow is referenced by its alias.
+func (c *KeycloakClient) UpdateAuthenticationExecutionRequirement(realm string, flowAlias string, id string, requirement string) error {
+	url := fmt.Sprintf(authenticationFlowExecutionsUri, c.url, realm, flowAlias)
+	return c.put(url, authenticationExecutionInfo{Id: id, Requirement: requirement})
+}
+
+// Deleting an execution that runs a subflow also deletes the subflow.
+func (c *KeycloakClient) DeleteAuthenticationExecution(realm string, id string) error {
+	url := fmt.Sprintf(authenticationExecutionUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
+
+func (c *KeycloakClient) GetAuthenticationExecutionConfig(realm string, id string) (*AuthenticationExecutionConfig, error) {
+	url := fmt.Sprintf(authenticationConfigUri, c.url, realm, id)
+
+	var config AuthenticationExecutionConfig
+	err := c.get(url, &config)
+
+	return &config, err
+}
+
+func (c *KeycloakClient) CreateAuthenticationExecutionConfig(realm string, executionId string, config *AuthenticationExecutionConfig) (*AuthenticationExecutionConfig, error) {
+	url := fmt.Sprintf(authenticationExecutionNewConfigUri, c.url, realm, executionId)
+	configLocation, err := c.post(url, *config)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdConfig AuthenticationExecutionConfig
+	err = c.get(configLocation, &createdConfig)
+
+	return &createdConfig, err
+}
+
+func (c *KeycloakClient) UpdateAuthenticationExecutionConfig(realm string, config *AuthenticationExecutionConfig) error {
+	url := fmt.Sprintf(authenticationConfigUri, c.url, realm, config.Id)
+	return c.put(url, *config)
+}
+
+func (c *KeycloakClient) DeleteAuthenticationExecutionConfig(realm string, id string) error {
+	url := fmt.Sprintf(authenticationConfigUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
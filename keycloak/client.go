@@ -2,6 +2,7 @@ package keycloak
 
 import (
 	"fmt"
+	"net/url"
 )
 
 // Client resource as documented in the Keycloak REST API docs.
@@ -11,14 +12,40 @@ import (
 type Client struct {
 	Id                      string   `json:"id,omitempty"`
 	ClientId                string   `json:"clientId"`
+	Name                    string   `json:"name"`
+	Description             string   `json:"description"`
 	Enabled                 bool     `json:"enabled"`
 	ClientAuthenticatorType string   `json:"clientAuthenticatorType,omitempty"`
+	Secret                  string   `json:"secret,omitempty"`
 	RedirectUris            []string `json:"redirectUris"`
 	Protocol                string   `json:"protocol,omitempty"`
 	PublicClient            bool     `json:"publicClient"`
 	BearerOnly              bool     `json:"bearerOnly"`
 	ServiceAccountsEnabled  bool     `json:"serviceAccountsEnabled"`
 	WebOrigins              []string `json:"webOrigins"`
+
+	RootUrl  string `json:"rootUrl"`
+	BaseUrl  string `json:"baseUrl"`
+	AdminUrl string `json:"adminUrl"`
+
+	// Settings that are not sent are left untouched by Keycloak, which keeps its protocol specific defaults.
+	StandardFlowEnabled       *bool `json:"standardFlowEnabled,omitempty"`
+	ImplicitFlowEnabled       *bool `json:"implicitFlowEnabled,omitempty"`
+	DirectAccessGrantsEnabled *bool `json:"directAccessGrantsEnabled,omitempty"`
+	ConsentRequired           *bool `json:"consentRequired,omitempty"`
+	FullScopeAllowed          *bool `json:"fullScopeAllowed,omitempty"`
+	FrontchannelLogout        *bool `json:"frontchannelLogout,omitempty"`
+
+	// IDs of authentication flows that replace the realm's flows for this client, keyed by 'browser' and
+	// 'direct_grant'. Overrides with an empty ID are removed.
+	AuthenticationFlowBindingOverrides map[string]string `json:"authenticationFlowBindingOverrides,omitempty"`
+
+	// Names of the client roles that Keycloak versions before 13 assign to new users. This is read-only.
+	DefaultRoles []string `json:"defaultRoles,omitempty"`
+
+	// Protocol specific settings (e.g. everything SAML related) are stored as string attributes.
+	// Keycloak merges attributes on update, so attributes that are not sent are left untouched.
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
 
 type ClientSecret struct {
@@ -27,10 +54,10 @@ type ClientSecret struct {
 }
 
 const (
-	clientUri       = "%s/auth/admin/realms/%s/clients/%s"
-	clientList      = "%s/auth/admin/realms/%s/clients"
-	clientSecretUri = "%s/auth/admin/realms/%s/clients/%s/client-secret"
-	clientUserUri   = "%s/auth/admin/realms/%s/clients/%s/service-account-user"
+	clientUri       = "%s/admin/realms/%s/clients/%s"
+	clientList      = "%s/admin/realms/%s/clients"
+	clientSecretUri = "%s/admin/realms/%s/clients/%s/client-secret"
+	clientUserUri   = "%s/admin/realms/%s/clients/%s/service-account-user"
 )
 
 func (c *KeycloakClient) GetClient(id string, realm string) (*Client, error) {
@@ -46,6 +73,15 @@ func (c *KeycloakClient) GetClient(id string, realm string) (*Client, error) {
 	return &client, nil
 }
 
+func (c *KeycloakClient) GetClients(realm string) ([]Client, error) {
+	url := fmt.Sprintf(clientList, c.url, realm)
+
+	var clients []Client
+	err := c.get(url, &clients)
+
+	return clients, err
+}
+
 func (c *KeycloakClient) GetClientSecret(id string, realm string) (*ClientSecret, error) {
 	url := fmt.Sprintf(clientSecretUri, c.url, realm, id)
 
@@ -59,10 +95,26 @@ func (c *KeycloakClient) GetClientSecret(id string, realm string) (*ClientSecret
 	return &secret, nil
 }
 
+// Replaces the secret of a confidential client with a new one generated by Keycloak.
+func (c *KeycloakClient) RegenerateClientSecret(id string, realm string) (*ClientSecret, error) {
+	url := fmt.Sprintf(clientSecretUri, c.url, realm, id)
+
+	var secret ClientSecret
+	err := c.postForResult(url, nil, &secret)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &secret, nil
+}
+
 // Attempt to create a Keycloak client and return the created client.
 func (c *KeycloakClient) CreateClient(client *Client, realm string) (*Client, error) {
 	url := fmt.Sprintf(clientList, c.url, realm)
-	clientLocation, err := c.post(url, *client)
+	clientLocation, err := c.create(url, *client, func() (string, error) {
+		return c.clientLocation(client.ClientId, realm)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +125,21 @@ func (c *KeycloakClient) CreateClient(client *Client, realm string) (*Client, er
 	return &createdClient, err
 }
 
+// Looks up the location of a client by its client ID, which is unique within a realm.
+func (c *KeycloakClient) clientLocation(clientId string, realm string) (string, error) {
+	var clients []Client
+	err := c.get(fmt.Sprintf(clientList, c.url, realm)+"?clientId="+url.QueryEscape(clientId), &clients)
+	if err != nil {
+		return "", err
+	}
+
+	if len(clients) == 0 {
+		return "", fmt.Errorf("client %s was not found in realm %s", clientId, realm)
+	}
+
+	return fmt.Sprintf(clientUri, c.url, realm, clients[0].Id), nil
+}
+
 func (c *KeycloakClient) UpdateClient(client *Client, realm string) error {
 	url := fmt.Sprintf(clientUri, c.url, realm, client.Id)
 	err := c.put(url, *client)
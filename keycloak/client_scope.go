@@ -0,0 +1,120 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Client scope resource as documented in the Keycloak REST API docs.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_clientscoperepresentation
+type ClientScope struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Protocol    string `json:"protocol"`
+
+	// Settings such as the consent screen text are stored as string attributes.
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Client scopes are assigned either as default scopes, which are always applied to a client's tokens,
+// or as optional scopes, which are only applied if the client requests them with the 'scope' parameter.
+// The realm's assignments are applied to newly created clients.
+type ClientScopeAssignment string
+
+const (
+	DefaultClientScopes  ClientScopeAssignment = "default"
+	OptionalClientScopes ClientScopeAssignment = "optional"
+)
+
+const (
+	clientScopesUri = "%s/admin/realms/%s/client-scopes"
+	clientScopeUri  = "%s/admin/realms/%s/client-scopes/%s"
+
+	// e.g. 'default-default-client-scopes' and 'default-optional-client-scopes'
+	realmClientScopesUri = "%s/admin/realms/%s/default-%s-client-scopes"
+	realmClientScopeUri  = "%s/admin/realms/%s/default-%s-client-scopes/%s"
+
+	// e.g. 'default-client-scopes' and 'optional-client-scopes'
+	clientClientScopesUri = "%s/admin/realms/%s/clients/%s/%s-client-scopes"
+	clientClientScopeUri  = "%s/admin/realms/%s/clients/%s/%s-client-scopes/%s"
+)
+
+func (c *KeycloakClient) GetClientScope(realm string, id string) (*ClientScope, error) {
+	url := fmt.Sprintf(clientScopeUri, c.url, realm, id)
+
+	var scope ClientScope
+	err := c.get(url, &scope)
+
+	return &scope, err
+}
+
+func (c *KeycloakClient) GetClientScopes(realm string) ([]ClientScope, error) {
+	url := fmt.Sprintf(clientScopesUri, c.url, realm)
+
+	var scopes []ClientScope
+	err := c.get(url, &scopes)
+
+	return scopes, err
+}
+
+func (c *KeycloakClient) CreateClientScope(realm string, scope *ClientScope) (*ClientScope, error) {
+	url := fmt.Sprintf(clientScopesUri, c.url, realm)
+	scopeLocation, err := c.post(url, *scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdScope ClientScope
+	err = c.get(scopeLocation, &createdScope)
+
+	return &createdScope, err
+}
+
+func (c *KeycloakClient) UpdateClientScope(realm string, scope *ClientScope) error {
+	url := fmt.Sprintf(clientScopeUri, c.url, realm, scope.Id)
+	return c.put(url, *scope)
+}
+
+func (c *KeycloakClient) DeleteClientScope(realm string, id string) error {
+	url := fmt.Sprintf(clientScopeUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
+
+// Returns the client scopes that the realm assigns to new clients.
+func (c *KeycloakClient) GetRealmClientScopes(realm string, assignment ClientScopeAssignment) ([]ClientScope, error) {
+	url := fmt.Sprintf(realmClientScopesUri, c.url, realm, assignment)
+
+	var scopes []ClientScope
+	err := c.get(url, &scopes)
+
+	return scopes, err
+}
+
+func (c *KeycloakClient) AddRealmClientScope(realm string, assignment ClientScopeAssignment, scopeId string) error {
+	url := fmt.Sprintf(realmClientScopeUri, c.url, realm, assignment, scopeId)
+	return c.put(url, nil)
+}
+
+func (c *KeycloakClient) RemoveRealmClientScope(realm string, assignment ClientScopeAssignment, scopeId string) error {
+	url := fmt.Sprintf(realmClientScopeUri, c.url, realm, assignment, scopeId)
+	return c.delete(url, nil)
+}
+
+func (c *KeycloakClient) GetClientClientScopes(realm string, clientId string, assignment ClientScopeAssignment) ([]ClientScope, error) {
+	url := fmt.Sprintf(clientClientScopesUri, c.url, realm, clientId, assignment)
+
+	var scopes []ClientScope
+	err := c.get(url, &scopes)
+
+	return scopes, err
+}
+
+func (c *KeycloakClient) AddClientClientScope(realm string, clientId string, assignment ClientScopeAssignment, scopeId string) error {
+	url := fmt.Sprintf(clientClientScopeUri, c.url, realm, clientId, assignment, scopeId)
+	return c.put(url, nil)
+}
+
+func (c *KeycloakClient) RemoveClientClientScope(realm string, clientId string, assignment ClientScopeAssignment, scopeId string) error {
+	url := fmt.Sprintf(clientClientScopeUri, c.url, realm, clientId, assignment, scopeId)
+	return c.delete(url, nil)
+}
@@ -0,0 +1,56 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Component resource as documented in the Keycloak REST API docs. Components are the generic extension points
+// of Keycloak, e.g. user federation providers and their mappers. Config values are lists of strings, although
+// most settings only use a single value.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_componentrepresentation
+type Component struct {
+	Id           string              `json:"id,omitempty"`
+	Name         string              `json:"name"`
+	ProviderId   string              `json:"providerId"`
+	ProviderType string              `json:"providerType"`
+	ParentId     string              `json:"parentId"`
+	SubType      string              `json:"subType,omitempty"`
+	Config       map[string][]string `json:"config"`
+}
+
+const (
+	componentsUri = "%s/admin/realms/%s/components"
+	componentUri  = "%s/admin/realms/%s/components/%s"
+)
+
+func (c *KeycloakClient) GetComponent(realm string, id string) (*Component, error) {
+	url := fmt.Sprintf(componentUri, c.url, realm, id)
+
+	var component Component
+	err := c.get(url, &component)
+
+	return &component, err
+}
+
+func (c *KeycloakClient) CreateComponent(realm string, component *Component) (*Component, error) {
+	url := fmt.Sprintf(componentsUri, c.url, realm)
+	componentLocation, err := c.post(url, *component)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdComponent Component
+	err = c.get(componentLocation, &createdComponent)
+
+	return &createdComponent, err
+}
+
+func (c *KeycloakClient) UpdateComponent(realm string, component *Component) error {
+	url := fmt.Sprintf(componentUri, c.url, realm, component.Id)
+	return c.put(url, *component)
+}
+
+func (c *KeycloakClient) DeleteComponent(realm string, id string) error {
+	url := fmt.Sprintf(componentUri, c.url, realm, id)
+	return c.delete(url, nil)
+}
@@ -0,0 +1,79 @@
+package keycloak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// An error response returned by the Keycloak API.
+type ApiError struct {
+	StatusCode int
+	Method     string
+	Url        string
+
+	// Keycloak's error message if the response contained one, the raw response body otherwise.
+	Message string
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%s %s failed: %s (%d)", e.Method, e.Url, e.Message, e.StatusCode)
+}
+
+// Returned when a resource is looked up in a list returned by Keycloak and is not part of it.
+type NotFoundError struct {
+	Message string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Message
+}
+
+// Returns true if the error is a Keycloak API error with status 404 or a NotFoundError, i.e. the
+// requested resource does not exist (anymore).
+func IsNotFound(err error) bool {
+	if _, ok := err.(*NotFoundError); ok {
+		return true
+	}
+
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// Returns true if the error is a Keycloak API error with status 409, which Keycloak returns when
+// creating a resource that already exists.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	apiErr, ok := err.(*ApiError)
+	return ok && apiErr.StatusCode == statusCode
+}
+
+func newApiError(resp *http.Response, body []byte) *ApiError {
+	// Admin API errors use 'errorMessage', while OAuth2 style errors use 'error' and 'error_description'.
+	var keycloakError struct {
+		ErrorMessage     string `json:"errorMessage"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	message := string(body)
+	if json.Unmarshal(body, &keycloakError) == nil {
+		switch {
+		case keycloakError.ErrorMessage != "":
+			message = keycloakError.ErrorMessage
+		case keycloakError.ErrorDescription != "":
+			message = keycloakError.ErrorDescription
+		case keycloakError.Error != "":
+			message = keycloakError.Error
+		}
+	}
+
+	return &ApiError{
+		StatusCode: resp.StatusCode,
+		Method:     resp.Request.Method,
+		Url:        resp.Request.URL.String(),
+		Message:    message,
+	}
+}
@@ -2,6 +2,8 @@ package keycloak
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 type Group struct {
@@ -11,16 +13,46 @@ type Group struct {
 	RealmRoles  []string          `json:"realmRoles,omitempty"`
 	ClientRoles map[string]string `json:"clientRoles,omitempty"`
 	SubGroups   []Group           `json:"subgroups,omitempty"`
+
+	// The full path of the group in the hierarchy, e.g. '/parent/child'. This is read-only.
+	Path string `json:"path,omitempty"`
+
+	// The ID of the parent group, which is only returned by newer Keycloak versions. This is read-only.
+	ParentId string `json:"parentId,omitempty"`
 }
 
 const (
-	groupUri  = "%s/auth/admin/realms/%s/groups/%s"
-	groupList = "%s/auth/admin/realms/%s/groups"
+	groupUri         = "%s/admin/realms/%s/groups/%s"
+	groupList        = "%s/admin/realms/%s/groups"
+	groupChildrenUri = "%s/admin/realms/%s/groups/%s/children"
+	groupByPathUri   = "%s/admin/realms/%s/group-by-path/%s"
 )
 
 func (c *KeycloakClient) AddGroup(group *Group, realm string) (*Group, error) {
 	url := fmt.Sprintf(groupList, c.url, realm)
 
+	// Only top-level group names are unique, child groups are therefore not retried after uncertain failures.
+	groupLocation, err := c.create(url, *group, func() (string, error) {
+		existing, err := c.GetGroupByPath("/"+group.Name, realm)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(groupUri, c.url, realm, existing.Id), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var createdGroup Group
+	err = c.get(groupLocation, &createdGroup)
+
+	return &createdGroup, err
+}
+
+// Creates a group as a child of the given parent group.
+func (c *KeycloakClient) AddChildGroup(group *Group, parentId string, realm string) (*Group, error) {
+	url := fmt.Sprintf(groupChildrenUri, c.url, realm, parentId)
+
 	groupLocation, err := c.post(url, *group)
 	if err != nil {
 		return nil, err
@@ -32,6 +64,60 @@ func (c *KeycloakClient) AddGroup(group *Group, realm string) (*Group, error) {
 	return &createdGroup, err
 }
 
+// Moves an existing group below the given parent group, or to the top level if no parent is given.
+func (c *KeycloakClient) MoveGroup(group *Group, parentId string, realm string) error {
+	url := fmt.Sprintf(groupList, c.url, realm)
+	if parentId != "" {
+		url = fmt.Sprintf(groupChildrenUri, c.url, realm, parentId)
+	}
+
+	_, err := c.post(url, *group)
+	return err
+}
+
+// Attempt to look up group by its full path, e.g. '/parent/child'
+func (c *KeycloakClient) GetGroupByPath(path string, realm string) (*Group, error) {
+	url := fmt.Sprintf(groupByPathUri, c.url, realm, escapeGroupPath(path))
+
+	var group Group
+	err := c.get(url, &group)
+
+	return &group, err
+}
+
+// Returns the ID of the parent of the given group, or an empty string for top-level groups.
+// Older Keycloak versions do not include the parent in group representations, so it is looked up by path.
+func (c *KeycloakClient) GetGroupParentId(group *Group, realm string) (string, error) {
+	if group.ParentId != "" {
+		return group.ParentId, nil
+	}
+
+	// The group's own name is cut off as a whole, as it may contain slashes.
+	parentPath := strings.TrimSuffix(group.Path, "/"+group.Name)
+	if parentPath == group.Path {
+		parentPath = group.Path[:strings.LastIndex(group.Path, "/")+1]
+	}
+	if parentPath == "" || parentPath == "/" {
+		return "", nil
+	}
+
+	parent, err := c.GetGroupByPath(parentPath, realm)
+	if err != nil {
+		return "", err
+	}
+
+	return parent.Id, nil
+}
+
+// Escapes every segment of a group path, so that group names may contain characters that are reserved in URLs.
+func escapeGroupPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
 // Attempt to look up group by given group ID
 func (c *KeycloakClient) GetGroup(groupId string, realm string) (*Group, error) {
 	url := fmt.Sprintf(groupUri, c.url, realm, groupId)
@@ -11,8 +11,8 @@ type UserGroupMap struct {
 }
 
 const (
-	userGroupsUri = "%s/auth/admin/realms/%s/users/%s/groups/%s"
-	getUsersUri   = "%s/auth/admin/realms/%s/groups/%s/members"
+	userGroupsUri = "%s/admin/realms/%s/users/%s/groups/%s"
+	getUsersUri   = "%s/admin/realms/%s/groups/%s/members"
 )
 
 func (c *KeycloakClient) GetUsersInGroup(groupId string, realm string) (*UserGroupMap, error) {
@@ -0,0 +1,57 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// The roles that are directly assigned to a group or user. Client role mappings are keyed by the
+// (human-readable) client ID.
+type RoleMappings struct {
+	RealmMappings  []RoleRepresentation          `json:"realmMappings"`
+	ClientMappings map[string]ClientRoleMappings `json:"clientMappings"`
+}
+
+type ClientRoleMappings struct {
+	// The internal ID of the client
+	Id       string               `json:"id"`
+	Client   string               `json:"client"`
+	Mappings []RoleRepresentation `json:"mappings"`
+}
+
+const (
+	groupRoleMappingsUri       = "%s/admin/realms/%s/groups/%s/role-mappings"
+	groupRealmRoleMappingsUri  = "%s/admin/realms/%s/groups/%s/role-mappings/realm"
+	groupClientRoleMappingsUri = "%s/admin/realms/%s/groups/%s/role-mappings/clients/%s"
+)
+
+func (c *KeycloakClient) GetGroupRoleMappings(realm string, groupId string) (*RoleMappings, error) {
+	url := fmt.Sprintf(groupRoleMappingsUri, c.url, realm, groupId)
+
+	var mappings RoleMappings
+	err := c.get(url, &mappings)
+
+	return &mappings, err
+}
+
+func (c *KeycloakClient) AddRealmRolesToGroup(realm string, groupId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(groupRealmRoleMappingsUri, c.url, realm, groupId)
+	_, err := c.post(url, roles)
+	return err
+}
+
+func (c *KeycloakClient) RemoveRealmRolesFromGroup(realm string, groupId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(groupRealmRoleMappingsUri, c.url, realm, groupId)
+	return c.delete(url, roles)
+}
+
+// The client ID is the internal ID of the client, not its human-readable client ID.
+func (c *KeycloakClient) AddClientRolesToGroup(realm string, groupId string, clientId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(groupClientRoleMappingsUri, c.url, realm, groupId, clientId)
+	_, err := c.post(url, roles)
+	return err
+}
+
+func (c *KeycloakClient) RemoveClientRolesFromGroup(realm string, groupId string, clientId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(groupClientRoleMappingsUri, c.url, realm, groupId, clientId)
+	return c.delete(url, roles)
+}
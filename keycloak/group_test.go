@@ -0,0 +1,37 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGroupParentIsLookedUpByEscapedPath(t *testing.T) {
+	requestedPath := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.EscapedPath()
+		fmt.Fprint(w, `{"id":"parent-id","name":"R&D #1"}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClientWithToken("token", server.URL, WithBasePath(""))
+
+	parentId, err := c.GetGroupParentId(&Group{Name: "ops/on-call", Path: "/R&D #1/ops/on-call"}, "test")
+	if err != nil || parentId != "parent-id" {
+		t.Fatalf("Unexpected parent %q: %v", parentId, err)
+	}
+
+	if requestedPath != "/admin/realms/test/group-by-path/R&D%20%231" {
+		t.Errorf("Parent was looked up with path %s", requestedPath)
+	}
+}
+
+func TestGroupParentIdIsUsedIfReturned(t *testing.T) {
+	c, _ := NewClientWithToken("token", "http://keycloak.invalid", WithBasePath(""))
+
+	parentId, err := c.GetGroupParentId(&Group{Name: "child", Path: "/parent/child", ParentId: "parent-id"}, "test")
+	if err != nil || parentId != "parent-id" {
+		t.Errorf("Unexpected parent %q: %v", parentId, err)
+	}
+}
@@ -0,0 +1,61 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Identity provider resource as documented in the Keycloak REST API docs. Identity providers are identified
+// by their alias, the protocol specific settings are stored as string config entries.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_identityproviderrepresentation
+type IdentityProvider struct {
+	Alias                     string            `json:"alias"`
+	DisplayName               string            `json:"displayName"`
+	ProviderId                string            `json:"providerId"`
+	Enabled                   bool              `json:"enabled"`
+	TrustEmail                bool              `json:"trustEmail"`
+	StoreToken                bool              `json:"storeToken"`
+	AddReadTokenRoleOnCreate  bool              `json:"addReadTokenRoleOnCreate"`
+	LinkOnly                  bool              `json:"linkOnly"`
+	FirstBrokerLoginFlowAlias string            `json:"firstBrokerLoginFlowAlias"`
+	PostBrokerLoginFlowAlias  string            `json:"postBrokerLoginFlowAlias"`
+	Config                    map[string]string `json:"config"`
+}
+
+const (
+	identityProvidersUri = "%s/admin/realms/%s/identity-provider/instances"
+	identityProviderUri  = "%s/admin/realms/%s/identity-provider/instances/%s"
+)
+
+func (c *KeycloakClient) GetIdentityProvider(realm string, alias string) (*IdentityProvider, error) {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, alias)
+
+	var provider IdentityProvider
+	err := c.get(url, &provider)
+
+	return &provider, err
+}
+
+func (c *KeycloakClient) CreateIdentityProvider(realm string, provider *IdentityProvider) (*IdentityProvider, error) {
+	url := fmt.Sprintf(identityProvidersUri, c.url, realm)
+	providerLocation, err := c.create(url, *provider, func() (string, error) {
+		return fmt.Sprintf(identityProviderUri, c.url, realm, provider.Alias), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var createdProvider IdentityProvider
+	err = c.get(providerLocation, &createdProvider)
+
+	return &createdProvider, err
+}
+
+func (c *KeycloakClient) UpdateIdentityProvider(realm string, provider *IdentityProvider) error {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, provider.Alias)
+	return c.put(url, *provider)
+}
+
+func (c *KeycloakClient) DeleteIdentityProvider(realm string, alias string) error {
+	url := fmt.Sprintf(identityProviderUri, c.url, realm, alias)
+	return c.delete(url, nil)
+}
@@ -0,0 +1,53 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Identity provider mapper resource as documented in the Keycloak REST API docs. The config keys depend
+// on the type of mapper.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_identityprovidermapperrepresentation
+type IdentityProviderMapper struct {
+	Id                     string            `json:"id,omitempty"`
+	Name                   string            `json:"name"`
+	IdentityProviderAlias  string            `json:"identityProviderAlias"`
+	IdentityProviderMapper string            `json:"identityProviderMapper"`
+	Config                 map[string]string `json:"config"`
+}
+
+const (
+	identityProviderMappersUri = "%s/admin/realms/%s/identity-provider/instances/%s/mappers"
+	identityProviderMapperUri  = "%s/admin/realms/%s/identity-provider/instances/%s/mappers/%s"
+)
+
+func (c *KeycloakClient) GetIdentityProviderMapper(realm string, alias string, id string) (*IdentityProviderMapper, error) {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, alias, id)
+
+	var mapper IdentityProviderMapper
+	err := c.get(url, &mapper)
+
+	return &mapper, err
+}
+
+func (c *KeycloakClient) CreateIdentityProviderMapper(realm string, mapper *IdentityProviderMapper) (*IdentityProviderMapper, error) {
+	url := fmt.Sprintf(identityProviderMappersUri, c.url, realm, mapper.IdentityProviderAlias)
+	mapperLocation, err := c.post(url, *mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdMapper IdentityProviderMapper
+	err = c.get(mapperLocation, &createdMapper)
+
+	return &createdMapper, err
+}
+
+func (c *KeycloakClient) UpdateIdentityProviderMapper(realm string, mapper *IdentityProviderMapper) error {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, mapper.IdentityProviderAlias, mapper.Id)
+	return c.put(url, *mapper)
+}
+
+func (c *KeycloakClient) DeleteIdentityProviderMapper(realm string, alias string, id string) error {
+	url := fmt.Sprintf(identityProviderMapperUri, c.url, realm, alias, id)
+	return c.delete(url, nil)
+}
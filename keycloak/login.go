@@ -1,57 +1,345 @@
 package keycloak
 
 import (
-	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 type tokenResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
-	IdToken     string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IdToken          string `json:"id_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	RefreshExpiresIn int    `json:"refresh_expires_in"`
 }
 
 const (
-	tokenEndpoint   = "%s/auth/realms/%s/protocol/openid-connect/token"
+	tokenEndpoint = "%s/realms/%s/protocol/openid-connect/token"
+	realmInfoUri  = "%s/realms/%s"
+
+	// The base path of Keycloak distributions prior to Keycloak 17.
+	legacyBasePath  = "/auth"
 	formContentType = "application/x-www-form-urlencoded"
-	loginBody       = "grant_type=client_credentials"
+
+	// Tokens are renewed this long before their actual expiry to account for clock skew
+	// and the time it takes for a request to reach Keycloak.
+	tokenExpiryLeeway = 10 * time.Second
 )
 
-// Attempt to login to Keycloak with the provided information.
-func Login(id string, secret string, baseUrl string, realm string) (*KeycloakClient, error) {
-	url := fmt.Sprintf(tokenEndpoint, baseUrl, realm)
+// Attempt to login to Keycloak with the provided client credentials.
+func Login(id string, secret string, baseUrl string, realm string, options ...ClientOption) (*KeycloakClient, error) {
+	client := &KeycloakClient{
+		baseUrl:      baseUrl,
+		realm:        realm,
+		clientId:     id,
+		clientSecret: secret,
+	}
 
-	req, _ := http.NewRequest("POST", url, bytes.NewBufferString(loginBody))
-	req.Header.Set("Authorization", createBasicAuthorizationHeader(id, secret))
-	req.Header.Set("Content-Type", formContentType)
+	err := client.configure(options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.login()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Attempt to login to Keycloak as a user with the resource owner password grant. The secret
+// may be left empty for public clients such as the built-in 'admin-cli' client.
+func LoginWithPassword(id string, secret string, username string, password string, baseUrl string, realm string, options ...ClientOption) (*KeycloakClient, error) {
+	client := &KeycloakClient{
+		baseUrl:      baseUrl,
+		realm:        realm,
+		clientId:     id,
+		clientSecret: secret,
+		username:     username,
+		password:     password,
+	}
+
+	err := client.configure(options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.login()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Creates a client that authenticates with a pre-issued access token. The token can not be renewed,
+// so all requests fail once it has expired.
+func NewClientWithToken(token string, baseUrl string, options ...ClientOption) (*KeycloakClient, error) {
+	client := &KeycloakClient{
+		baseUrl: baseUrl,
+	}
+
+	err := client.configure(options)
+	if err != nil {
+		return nil, err
+	}
+
+	client.setAccessToken(token)
+	return client, nil
+}
+
+// Creates a client that authenticates with an access token read from the given file. The file is
+// read again whenever the token expires or is rejected by Keycloak.
+func NewClientWithTokenFile(path string, baseUrl string, options ...ClientOption) (*KeycloakClient, error) {
+	client := &KeycloakClient{
+		baseUrl:   baseUrl,
+		tokenFile: path,
+	}
 
-	resp, err := http.DefaultClient.Do(req)
+	err := client.configure(options)
 	if err != nil {
 		return nil, err
 	}
 
+	err = client.login()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (c *KeycloakClient) configure(options []ClientOption) error {
+	c.baseUrl = strings.TrimRight(c.baseUrl, "/")
+	c.httpClient = http.DefaultClient
+
+	for _, option := range options {
+		option(c)
+	}
+
+	if !c.basePathConfigured {
+		basePath, err := c.detectBasePath()
+		if err != nil {
+			return err
+		}
+
+		c.basePath = basePath
+	}
+
+	c.url = c.baseUrl + c.basePath
+	return nil
+}
+
+// Detects whether Keycloak is served from the legacy '/auth' base path by looking up the public
+// information of the master realm, which exists in every Keycloak installation.
+func (c *KeycloakClient) detectBasePath() (string, error) {
+	probeUrl := fmt.Sprintf(realmInfoUri, c.baseUrl+legacyBasePath, "master")
+
+	resp, err := c.httpClient.Get(probeUrl)
+	if err != nil {
+		return "", fmt.Errorf("Could not detect Keycloak base path: %s", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return legacyBasePath, nil
+	}
+
+	log.Printf("[DEBUG] Keycloak is not served from %s (%d), using the root path", legacyBasePath, resp.StatusCode)
+	return "", nil
+}
+
+// Returns a valid access token, renewing it first if it is about to expire.
+func (c *KeycloakClient) accessToken() (string, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	if c.token != "" && !isExpired(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	err := c.renewToken()
+	return c.token, err
+}
+
+// Replaces an access token that has been rejected by Keycloak. If another request has already
+// renewed the token in the meantime, the new token is returned instead of logging in again.
+func (c *KeycloakClient) renewRejectedToken(rejected string) (string, error) {
+	c.tokenLock.Lock()
+	defer c.tokenLock.Unlock()
+
+	if c.token != rejected {
+		return c.token, nil
+	}
+
+	err := c.login()
+	return c.token, err
+}
+
+// Renews the access token using the refresh token if possible and falls back to a full login
+// otherwise. Must be called with tokenLock held.
+func (c *KeycloakClient) renewToken() error {
+	if c.refreshToken != "" && !isExpired(c.refreshTokenExpiry) {
+		err := c.refresh()
+		if err == nil {
+			return nil
+		}
+
+		// Refresh tokens can be invalidated early, e.g. when the session is terminated.
+		log.Printf("[DEBUG] Refreshing Keycloak access token failed, logging in again: %s", err)
+	}
+
+	return c.login()
+}
+
+// Performs the configured grant (or reads the token file) and stores the resulting tokens.
+func (c *KeycloakClient) login() error {
+	if c.tokenFile != "" {
+		return c.readTokenFile()
+	}
+
+	if c.clientId == "" {
+		return fmt.Errorf("The configured Keycloak access token has expired or was rejected and can not be renewed")
+	}
+
+	if c.username != "" {
+		return c.requestToken(url.Values{
+			"grant_type": {"password"},
+			"username":   {c.username},
+			"password":   {c.password},
+		})
+	}
+
+	return c.requestToken(url.Values{
+		"grant_type": {"client_credentials"},
+	})
+}
+
+func (c *KeycloakClient) refresh() error {
+	return c.requestToken(url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {c.refreshToken},
+	})
+}
+
+func (c *KeycloakClient) requestToken(form url.Values) error {
+	tokenUrl := fmt.Sprintf(tokenEndpoint, c.url, c.realm)
+
+	// Public clients have no secret and identify themselves in the request body instead.
+	if c.clientSecret == "" {
+		form.Set("client_id", c.clientId)
+	}
+
+	req, _ := http.NewRequest("POST", tokenUrl, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", formContentType)
+	if c.clientSecret != "" {
+		req.Header.Set("Authorization", createBasicAuthorizationHeader(c.clientId, c.clientSecret))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
 	defer resp.Body.Close()
 	body, _ := ioutil.ReadAll(resp.Body)
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("Keycloak login failed: %s (%d)", string(body), resp.StatusCode)
+		return fmt.Errorf("Keycloak login failed: %s", newApiError(resp, body))
 	}
 
 	var t tokenResponse
 	err = json.Unmarshal(body, &t)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	client := &KeycloakClient{
-		token: t.AccessToken,
-		url:   baseUrl,
+	c.setToken(&t)
+	return nil
+}
+
+func (c *KeycloakClient) readTokenFile() error {
+	content, err := ioutil.ReadFile(c.tokenFile)
+	if err != nil {
+		return fmt.Errorf("Could not read Keycloak access token file: %s", err)
 	}
-	return client, nil
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return fmt.Errorf("Keycloak access token file %s is empty", c.tokenFile)
+	}
+
+	c.setAccessToken(token)
+	return nil
+}
+
+// Stores an access token that was not issued to this client. Its expiry is taken from the token itself
+// if it is a JWT, as is the case for all tokens issued by Keycloak.
+func (c *KeycloakClient) setAccessToken(token string) {
+	c.token = token
+	c.tokenExpiry = jwtExpiry(token)
+	c.refreshToken = ""
+	c.refreshTokenExpiry = time.Time{}
+}
+
+func (c *KeycloakClient) setToken(t *tokenResponse) {
+	now := time.Now()
+
+	c.token = t.AccessToken
+	c.tokenExpiry = expiryTime(now, t.ExpiresIn)
+	c.refreshToken = t.RefreshToken
+	c.refreshTokenExpiry = expiryTime(now, t.RefreshExpiresIn)
+}
+
+// Keycloak reports lifespans in seconds, where 0 means that the token does not expire
+// (e.g. offline tokens). This is represented by the zero time.
+func expiryTime(issued time.Time, lifespan int) time.Time {
+	if lifespan <= 0 {
+		return time.Time{}
+	}
+
+	return issued.Add(time.Duration(lifespan) * time.Second)
+}
+
+// Reads the 'exp' claim of a JWT without verifying it. Tokens that can not be parsed are treated as
+// having no known expiry and will only be replaced once Keycloak rejects them.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+func isExpired(expiry time.Time) bool {
+	if expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(tokenExpiryLeeway).After(expiry)
 }
 
 func createBasicAuthorizationHeader(id string, secret string) string {
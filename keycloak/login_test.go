@@ -0,0 +1,126 @@
+package keycloak
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+// Serves a token endpoint that issues numbered tokens and an API endpoint that only accepts
+// the most recently issued one.
+func newTokenTestServer(grants *[]string) *httptest.Server {
+	issued := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/auth/realms/master/protocol/openid-connect/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		*grants = append(*grants, r.PostForm.Get("grant_type"))
+		issued++
+		fmt.Fprintf(w, `{"access_token":"token-%d","refresh_token":"refresh-%d","expires_in":60,"refresh_expires_in":1800}`, issued, issued)
+	})
+	mux.HandleFunc("/auth/admin/realms/master", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", issued) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id":"master","realm":"master","enabled":true}`)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestExpiredTokenIsRefreshed(t *testing.T) {
+	var grants []string
+	server := newTokenTestServer(&grants)
+	defer server.Close()
+
+	c, err := Login("terraform", "secret", server.URL, "master", WithBasePath("/auth"))
+	if err != nil {
+		t.Fatalf("Login failed: %s", err)
+	}
+
+	c.tokenExpiry = time.Now().Add(-time.Minute)
+
+	if _, err := c.GetRealm("master"); err != nil {
+		t.Fatalf("Request with expired token failed: %s", err)
+	}
+
+	expected := []string{"client_credentials", "refresh_token"}
+	if fmt.Sprint(grants) != fmt.Sprint(expected) {
+		t.Errorf("Expected grants %v, got %v", expected, grants)
+	}
+}
+
+func TestRejectedTokenIsReplaced(t *testing.T) {
+	var grants []string
+	server := newTokenTestServer(&grants)
+	defer server.Close()
+
+	c, err := Login("terraform", "secret", server.URL, "master", WithBasePath("/auth"))
+	if err != nil {
+		t.Fatalf("Login failed: %s", err)
+	}
+
+	c.token = "revoked"
+
+	if _, err := c.GetRealm("master"); err != nil {
+		t.Fatalf("Request with rejected token was not retried: %s", err)
+	}
+
+	if c.token != "token-2" {
+		t.Errorf("Expected rejected token to be replaced, got %s", c.token)
+	}
+}
+
+func TestTokenFileIsReadAgainOnRejection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id":"master","realm":"master","enabled":true}`)
+	}))
+	defer server.Close()
+
+	file, err := ioutil.TempFile("", "keycloak-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	ioutil.WriteFile(file.Name(), []byte("initial\n"), 0600)
+	c, err := NewClientWithTokenFile(file.Name(), server.URL)
+	if err != nil {
+		t.Fatalf("Could not create client: %s", err)
+	}
+
+	ioutil.WriteFile(file.Name(), []byte("rotated\n"), 0600)
+	if _, err := c.GetRealm("master"); err != nil {
+		t.Fatalf("Token file was not read again after rejection: %s", err)
+	}
+}
+
+func TestBasePathDetection(t *testing.T) {
+	for basePath, served := range map[string]string{"/auth": "/auth/realms/master", "": "/realms/master"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != served {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		c, err := NewClientWithToken("token", server.URL+"/", WithHttpClient(server.Client()))
+		server.Close()
+
+		if err != nil {
+			t.Fatalf("Could not create client: %s", err)
+		}
+
+		if c.url != server.URL+basePath {
+			t.Errorf("Expected API URL %s, got %s", server.URL+basePath, c.url)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package keycloak
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Keycloak stores a realm's password policy as a single string of policies joined by " and ", e.g.
+// `length(12) and digits(1) and notUsername(undefined)`. Policies without a value are written with
+// the value "undefined", which is also what the admin console does.
+type PasswordPolicy struct {
+	Length                     int
+	MaxLength                  int
+	Digits                     int
+	LowerCase                  int
+	UpperCase                  int
+	SpecialChars               int
+	PasswordHistory            int
+	HashIterations             int
+	ForceExpiredPasswordChange int // in days
+	NotUsername                bool
+	NotEmail                   bool
+	HashAlgorithm              string
+	RegexPattern               string
+
+	// Policies that have no dedicated field, keyed by policy ID
+	Other map[string]string
+}
+
+const (
+	passwordPolicySeparator = " and "
+	passwordPolicyNoValue   = "undefined"
+)
+
+type intPasswordPolicy struct {
+	id    string
+	value *int
+}
+
+// Integer policies in the order in which they are serialized
+func (p *PasswordPolicy) intPolicies() []intPasswordPolicy {
+	return []intPasswordPolicy{
+		{"length", &p.Length},
+		{"maxLength", &p.MaxLength},
+		{"digits", &p.Digits},
+		{"lowerCase", &p.LowerCase},
+		{"upperCase", &p.UpperCase},
+		{"specialChars", &p.SpecialChars},
+		{"passwordHistory", &p.PasswordHistory},
+		{"hashIterations", &p.HashIterations},
+		{"forceExpiredPasswordChange", &p.ForceExpiredPasswordChange},
+	}
+}
+
+func ParsePasswordPolicy(policy string) *PasswordPolicy {
+	p := PasswordPolicy{Other: map[string]string{}}
+	ints := map[string]*int{}
+	for _, intPolicy := range p.intPolicies() {
+		ints[intPolicy.id] = intPolicy.value
+	}
+
+	for _, term := range strings.Split(policy, passwordPolicySeparator) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		id, value := term, passwordPolicyNoValue
+		if i := strings.Index(term, "("); i > 0 && strings.HasSuffix(term, ")") {
+			id, value = term[:i], term[i+1:len(term)-1]
+		}
+
+		switch id {
+		case "notUsername":
+			p.NotUsername = true
+		case "notEmail":
+			p.NotEmail = true
+		case "hashAlgorithm":
+			p.HashAlgorithm = value
+		case "regexPattern":
+			p.RegexPattern = value
+		default:
+			// Values that can't be represented are kept as they are, so that they aren't lost on the next update.
+			if target, ok := ints[id]; ok {
+				if i, err := strconv.Atoi(value); err == nil {
+					*target = i
+					continue
+				}
+			}
+			p.Other[id] = value
+		}
+	}
+
+	return &p
+}
+
+func (p *PasswordPolicy) String() string {
+	terms := []string{}
+	for _, intPolicy := range p.intPolicies() {
+		if *intPolicy.value != 0 {
+			terms = append(terms, fmt.Sprintf("%s(%d)", intPolicy.id, *intPolicy.value))
+		}
+	}
+
+	if p.NotUsername {
+		terms = append(terms, "notUsername("+passwordPolicyNoValue+")")
+	}
+	if p.NotEmail {
+		terms = append(terms, "notEmail("+passwordPolicyNoValue+")")
+	}
+	if p.HashAlgorithm != "" {
+		terms = append(terms, "hashAlgorithm("+p.HashAlgorithm+")")
+	}
+	if p.RegexPattern != "" {
+		terms = append(terms, "regexPattern("+p.RegexPattern+")")
+	}
+
+	ids := []string{}
+	for id := range p.Other {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		terms = append(terms, id+"("+p.Other[id]+")")
+	}
+
+	return strings.Join(terms, passwordPolicySeparator)
+}
@@ -0,0 +1,32 @@
+package keycloak
+
+import (
+	"testing"
+)
+
+func TestPasswordPolicyRoundTrip(t *testing.T) {
+	policy := "length(12) and digits(1) and passwordHistory(3) and notUsername(undefined) and hashAlgorithm(pbkdf2-sha256) and passwordBlacklist(words.txt)"
+
+	p := ParsePasswordPolicy(policy)
+	if p.Length != 12 || p.Digits != 1 || p.PasswordHistory != 3 || !p.NotUsername || p.HashAlgorithm != "pbkdf2-sha256" {
+		t.Fatalf("Policy was not parsed correctly: %+v", p)
+	}
+	if p.Other["passwordBlacklist"] != "words.txt" {
+		t.Errorf("Unknown policies were not retained: %v", p.Other)
+	}
+
+	if serialized := p.String(); serialized != policy {
+		t.Errorf("Expected %q, got %q", policy, serialized)
+	}
+}
+
+func TestPasswordPolicyWithoutValues(t *testing.T) {
+	p := ParsePasswordPolicy("notUsername and notEmail")
+	if !p.NotUsername || !p.NotEmail {
+		t.Errorf("Policies without values were not parsed: %+v", p)
+	}
+
+	if serialized := ParsePasswordPolicy("").String(); serialized != "" {
+		t.Errorf("Expected empty policy, got %q", serialized)
+	}
+}
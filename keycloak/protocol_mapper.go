@@ -0,0 +1,76 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Protocol mapper resource as documented in the Keycloak REST API docs. Mappers belong to either a client
+// or a client scope, and their config keys depend on the type of mapper.
+// http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_protocolmapperrepresentation
+type ProtocolMapper struct {
+	Id             string            `json:"id,omitempty"`
+	Name           string            `json:"name"`
+	Protocol       string            `json:"protocol"`
+	ProtocolMapper string            `json:"protocolMapper"`
+	Config         map[string]string `json:"config"`
+}
+
+const (
+	clientProtocolMappersUri      = "%s/admin/realms/%s/clients/%s/protocol-mappers/models"
+	clientScopeProtocolMappersUri = "%s/admin/realms/%s/client-scopes/%s/protocol-mappers/models"
+)
+
+// Protocol mapper methods take the ID of either the client or the client scope that the mapper belongs to.
+func (c *KeycloakClient) protocolMappersUrl(realm string, clientId string, clientScopeId string) string {
+	if clientId != "" {
+		return fmt.Sprintf(clientProtocolMappersUri, c.url, realm, clientId)
+	}
+
+	return fmt.Sprintf(clientScopeProtocolMappersUri, c.url, realm, clientScopeId)
+}
+
+func (c *KeycloakClient) CreateProtocolMapper(realm string, clientId string, clientScopeId string, mapper *ProtocolMapper) (*ProtocolMapper, error) {
+	mappersUrl := c.protocolMappersUrl(realm, clientId, clientScopeId)
+
+	mapperLocation, err := c.create(mappersUrl, *mapper, func() (string, error) {
+		var mappers []ProtocolMapper
+		err := c.get(mappersUrl, &mappers)
+		if err != nil {
+			return "", err
+		}
+
+		for _, existing := range mappers {
+			if existing.Name == mapper.Name {
+				return mappersUrl + "/" + existing.Id, nil
+			}
+		}
+		return "", fmt.Errorf("protocol mapper %s was not found", mapper.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var createdMapper ProtocolMapper
+	err = c.get(mapperLocation, &createdMapper)
+
+	return &createdMapper, err
+}
+
+func (c *KeycloakClient) GetProtocolMapper(realm string, clientId string, clientScopeId string, id string) (*ProtocolMapper, error) {
+	url := c.protocolMappersUrl(realm, clientId, clientScopeId) + "/" + id
+
+	var mapper ProtocolMapper
+	err := c.get(url, &mapper)
+
+	return &mapper, err
+}
+
+func (c *KeycloakClient) UpdateProtocolMapper(realm string, clientId string, clientScopeId string, mapper *ProtocolMapper) error {
+	url := c.protocolMappersUrl(realm, clientId, clientScopeId) + "/" + mapper.Id
+	return c.put(url, *mapper)
+}
+
+func (c *KeycloakClient) DeleteProtocolMapper(realm string, clientId string, clientScopeId string, id string) error {
+	url := c.protocolMappersUrl(realm, clientId, clientScopeId) + "/" + id
+	return c.delete(url, nil)
+}
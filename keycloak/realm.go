@@ -2,8 +2,51 @@ package keycloak
 
 import "fmt"
 
-// The available keys of the SMTP server map are not documented in Keycloak's API docs.
-type SmtpServer map[string]interface{}
+// The SMTP server settings are not documented in Keycloak's API docs. Keycloak stores all of them as strings, and
+// returns the password masked with asterisks. Sending the masked password back keeps the current password.
+type SmtpServer struct {
+	Host               string `json:"host,omitempty"`
+	Port               string `json:"port,omitempty"`
+	From               string `json:"from,omitempty"`
+	FromDisplayName    string `json:"fromDisplayName,omitempty"`
+	ReplyTo            string `json:"replyTo,omitempty"`
+	ReplyToDisplayName string `json:"replyToDisplayName,omitempty"`
+	EnvelopeFrom       string `json:"envelopeFrom,omitempty"`
+	Ssl                string `json:"ssl,omitempty"`
+	StartTls           string `json:"starttls,omitempty"`
+	Auth               string `json:"auth,omitempty"`
+	User               string `json:"user,omitempty"`
+	Password           string `json:"password,omitempty"`
+}
+
+// The WebAuthn policies used for two-factor and passwordless authentication have the same settings, which are
+// stored with different prefixes in the realm. Both types can be converted into each other. Keycloak rebuilds both
+// policies from every realm update, so fields that are nil are reset to Keycloak's defaults.
+type WebAuthnPolicy struct {
+	RpEntityName                    string   `json:"webAuthnPolicyRpEntityName,omitempty"`
+	RpId                            *string  `json:"webAuthnPolicyRpId,omitempty"`
+	SignatureAlgorithms             []string `json:"webAuthnPolicySignatureAlgorithms"`
+	AttestationConveyancePreference string   `json:"webAuthnPolicyAttestationConveyancePreference,omitempty"`
+	AuthenticatorAttachment         string   `json:"webAuthnPolicyAuthenticatorAttachment,omitempty"`
+	RequireResidentKey              string   `json:"webAuthnPolicyRequireResidentKey,omitempty"`
+	UserVerificationRequirement     string   `json:"webAuthnPolicyUserVerificationRequirement,omitempty"`
+	CreateTimeout                   *int     `json:"webAuthnPolicyCreateTimeout,omitempty"`
+	AvoidSameAuthenticatorRegister  *bool    `json:"webAuthnPolicyAvoidSameAuthenticatorRegister,omitempty"`
+	AcceptableAaguids               []string `json:"webAuthnPolicyAcceptableAaguids"`
+}
+
+type WebAuthnPasswordlessPolicy struct {
+	RpEntityName                    string   `json:"webAuthnPolicyPasswordlessRpEntityName,omitempty"`
+	RpId                            *string  `json:"webAuthnPolicyPasswordlessRpId,omitempty"`
+	SignatureAlgorithms             []string `json:"webAuthnPolicyPasswordlessSignatureAlgorithms"`
+	AttestationConveyancePreference string   `json:"webAuthnPolicyPasswordlessAttestationConveyancePreference,omitempty"`
+	AuthenticatorAttachment         string   `json:"webAuthnPolicyPasswordlessAuthenticatorAttachment,omitempty"`
+	RequireResidentKey              string   `json:"webAuthnPolicyPasswordlessRequireResidentKey,omitempty"`
+	UserVerificationRequirement     string   `json:"webAuthnPolicyPasswordlessUserVerificationRequirement,omitempty"`
+	CreateTimeout                   *int     `json:"webAuthnPolicyPasswordlessCreateTimeout,omitempty"`
+	AvoidSameAuthenticatorRegister  *bool    `json:"webAuthnPolicyPasswordlessAvoidSameAuthenticatorRegister,omitempty"`
+	AcceptableAaguids               []string `json:"webAuthnPolicyPasswordlessAcceptableAaguids"`
+}
 
 // Representation of top-level realm keys. According to the Keycloak documentation other keys than top-level keys will
 // be ignored on realm updates, which is why they are not included here.
@@ -21,6 +64,9 @@ type Realm struct {
 	DefaultRoles     []string    `json:"defaultRoles,omitempty"`
 	SmtpServer       *SmtpServer `json:"smtpServer,omitempty"`
 
+	// See PasswordPolicy for the format. An empty policy removes all password policies.
+	PasswordPolicy *string `json:"passwordPolicy,omitempty"`
+
 	AccountTheme string `json:"accountTheme,omitempty"`
 	AdminTheme   string `json:"adminTheme,omitempty"`
 	EmailTheme   string `json:"emailTheme,omitempty"`
@@ -35,6 +81,25 @@ type Realm struct {
 	EditUsernameAllowed         *bool `json:"editUsernameAllowed,omitempty"`
 	BruteForceProtected         *bool `json:"bruteForceProtected,omitempty"`
 
+	// OTP policy. The supported applications are derived from the policy by Keycloak.
+	OtpPolicyType            string   `json:"otpPolicyType,omitempty"` // totp or hotp
+	OtpPolicyAlgorithm       string   `json:"otpPolicyAlgorithm,omitempty"`
+	OtpPolicyDigits          *int     `json:"otpPolicyDigits,omitempty"`
+	OtpPolicyPeriod          *int     `json:"otpPolicyPeriod,omitempty"`
+	OtpPolicyLookAheadWindow *int     `json:"otpPolicyLookAheadWindow,omitempty"`
+	OtpPolicyInitialCounter  *int     `json:"otpPolicyInitialCounter,omitempty"`
+	OtpSupportedApplications []string `json:"otpSupportedApplications,omitempty"`
+
+	WebAuthnPolicy
+	WebAuthnPasswordlessPolicy
+
+	// Aliases of the authentication flows that are bound to the realm
+	BrowserFlow              string `json:"browserFlow,omitempty"`
+	RegistrationFlow         string `json:"registrationFlow,omitempty"`
+	DirectGrantFlow          string `json:"directGrantFlow,omitempty"`
+	ResetCredentialsFlow     string `json:"resetCredentialsFlow,omitempty"`
+	ClientAuthenticationFlow string `json:"clientAuthenticationFlow,omitempty"`
+
 	// Token & session settings
 	AccessTokenLifespan                *int `json:"accessTokenLifespan,omitempty"`
 	AccessTokenLifespanForImplicitFlow *int `json:"accessTokenLifespanForImplicitFlow,omitempty"`
@@ -53,8 +118,8 @@ type Realm struct {
 }
 
 const (
-	realmsUri = "%s/auth/admin/realms"
-	realmUri  = "%s/auth/admin/realms/%s"
+	realmsUri = "%s/admin/realms"
+	realmUri  = "%s/admin/realms/%s"
 )
 
 func (c *KeycloakClient) GetRealm(id string) (*Realm, error) {
@@ -70,7 +135,9 @@ func (c *KeycloakClient) GetRealm(id string) (*Realm, error) {
 func (c *KeycloakClient) CreateRealm(r *Realm) (*Realm, error) {
 	url := fmt.Sprintf(realmsUri, c.url)
 
-	realmLocation, err := c.post(url, *r)
+	realmLocation, err := c.create(url, *r, func() (string, error) {
+		return fmt.Sprintf(realmUri, c.url, r.Realm), nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Event configuration of a realm as documented in the Keycloak REST API docs. Login events and admin events are
+// stored separately, and every event is additionally passed to the configured event listeners.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_realmeventsconfigrepresentation
+type RealmEventsConfig struct {
+	EventsEnabled             bool     `json:"eventsEnabled"`
+	EventsExpiration          int      `json:"eventsExpiration"` // in seconds, 0 keeps events forever
+	EventsListeners           []string `json:"eventsListeners"`
+	EnabledEventTypes         []string `json:"enabledEventTypes"`
+	AdminEventsEnabled        bool     `json:"adminEventsEnabled"`
+	AdminEventsDetailsEnabled bool     `json:"adminEventsDetailsEnabled"`
+}
+
+const realmEventsConfigUri = "%s/admin/realms/%s/events/config"
+
+func (c *KeycloakClient) GetRealmEventsConfig(realm string) (*RealmEventsConfig, error) {
+	url := fmt.Sprintf(realmEventsConfigUri, c.url, realm)
+
+	var config RealmEventsConfig
+	err := c.get(url, &config)
+
+	return &config, err
+}
+
+func (c *KeycloakClient) UpdateRealmEventsConfig(realm string, config *RealmEventsConfig) error {
+	url := fmt.Sprintf(realmEventsConfigUri, c.url, realm)
+	return c.put(url, *config)
+}
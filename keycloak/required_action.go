@@ -0,0 +1,61 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+// Required action resource as documented in the Keycloak REST API docs. Required actions are identified by
+// their alias, which is the ID of the provider that implements them.
+// http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_requiredactionproviderrepresentation
+type RequiredAction struct {
+	Alias         string            `json:"alias"`
+	Name          string            `json:"name"`
+	ProviderId    string            `json:"providerId"`
+	Enabled       bool              `json:"enabled"`
+	DefaultAction bool              `json:"defaultAction"`
+	Priority      int               `json:"priority"`
+	Config        map[string]string `json:"config,omitempty"`
+}
+
+// A required action provider that is deployed to Keycloak but not yet available in the realm.
+type UnregisteredRequiredAction struct {
+	ProviderId string `json:"providerId"`
+	Name       string `json:"name"`
+}
+
+const (
+	requiredActionsUri             = "%s/admin/realms/%s/authentication/required-actions"
+	requiredActionUri              = "%s/admin/realms/%s/authentication/required-actions/%s"
+	unregisteredRequiredActionsUri = "%s/admin/realms/%s/authentication/unregistered-required-actions"
+	registerRequiredActionUri      = "%s/admin/realms/%s/authentication/register-required-action"
+)
+
+func (c *KeycloakClient) GetRequiredAction(realm string, alias string) (*RequiredAction, error) {
+	url := fmt.Sprintf(requiredActionUri, c.url, realm, alias)
+
+	var action RequiredAction
+	err := c.get(url, &action)
+
+	return &action, err
+}
+
+func (c *KeycloakClient) GetUnregisteredRequiredActions(realm string) ([]UnregisteredRequiredAction, error) {
+	url := fmt.Sprintf(unregisteredRequiredActionsUri, c.url, realm)
+
+	var actions []UnregisteredRequiredAction
+	err := c.get(url, &actions)
+
+	return actions, err
+}
+
+// Makes a required action provider available in the realm. Its alias is the provider ID.
+func (c *KeycloakClient) RegisterRequiredAction(realm string, action *UnregisteredRequiredAction) error {
+	url := fmt.Sprintf(registerRequiredActionUri, c.url, realm)
+	_, err := c.post(url, *action)
+	return err
+}
+
+func (c *KeycloakClient) UpdateRequiredAction(realm string, action *RequiredAction) error {
+	url := fmt.Sprintf(requiredActionUri, c.url, realm, action.Alias)
+	return c.put(url, *action)
+}
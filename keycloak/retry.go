@@ -0,0 +1,189 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Controls how requests that fail with a transient error (connection failures or 429, 502, 503
+// and 504 responses) are retried. The zero value disables retries.
+type RetryPolicy struct {
+	// The maximum number of attempts for each request, including the first one.
+	MaxAttempts int
+
+	// The wait time before the first retry, which doubles on every subsequent attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Randomises wait times so that concurrent requests do not all retry at the same moment.
+	Jitter bool
+}
+
+// Retry requests that fail with transient errors according to the given policy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *KeycloakClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// Returned for a retried create request that conflicts with an existing resource after an earlier attempt
+// may have been processed, which means that the resource was most likely created by that attempt.
+var errCreatedByEarlierAttempt = errors.New("resource was created by an earlier attempt")
+
+type createRequestKey struct{}
+
+// Marks a POST request as creating a resource that Keycloak rejects with a 409 if it already exists.
+func asCreateRequest(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), createRequestKey{}, true))
+}
+
+func isCreateRequest(req *http.Request) bool {
+	isCreate, _ := req.Context().Value(createRequestKey{}).(bool)
+	return isCreate
+}
+
+// Sends a request using the given function and retries it while it fails with transient errors.
+//
+// Retrying is always safe for idempotent requests. A POST however may have been processed even though
+// its response was lost, and not every POST is rejected when it is repeated (e.g. regenerating a client
+// secret or adding role mappings). POSTs are therefore only retried after failures that guarantee that
+// Keycloak did not act on them (see wasNotProcessed), unless they create a resource. Creating the same
+// resource twice is rejected with a 409, so a conflict after an uncertain failure shows that the resource
+// was created by an earlier attempt and is reported as errCreatedByEarlierAttempt.
+func (c *KeycloakClient) retry(req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	mayHaveBeenProcessed := false
+
+	for attempt := 1; ; attempt++ {
+		resp, err := send(req)
+
+		if mayHaveBeenProcessed && err == nil && resp.StatusCode == http.StatusConflict {
+			resp.Body.Close()
+			return nil, errCreatedByEarlierAttempt
+		}
+
+		if attempt >= c.retryPolicy.MaxAttempts || !isTransientFailure(resp, err) {
+			return resp, err
+		}
+
+		if req.Method == "POST" && !wasNotProcessed(resp, err) {
+			if !isCreateRequest(req) {
+				log.Printf("[DEBUG] %s %s failed and may have been processed by Keycloak, not retrying", req.Method, req.URL)
+				return resp, err
+			}
+			mayHaveBeenProcessed = true
+		}
+
+		wait := c.retryPolicy.backoff(attempt, resp)
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed (%s), retrying in %s", req.Method, req.URL, err, wait)
+		} else {
+			log.Printf("[DEBUG] %s %s failed (%d), retrying in %s", req.Method, req.URL, resp.StatusCode, wait)
+			resp.Body.Close()
+		}
+
+		time.Sleep(wait)
+
+		err = rewindBody(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func isTransientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return isConnectionFailure(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// Returns true for failures that guarantee that Keycloak did not act on a request.
+func wasNotProcessed(resp *http.Response, err error) bool {
+	if err != nil {
+		return isDialFailure(err)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// Connection failures and timeouts, as opposed to errors caused by the request itself (e.g. an invalid URL).
+func isConnectionFailure(err error) bool {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return false
+	}
+
+	if _, isOpError := urlErr.Err.(*net.OpError); isOpError || urlErr.Timeout() {
+		return true
+	}
+
+	// Keycloak closing a kept-alive connection during a restart surfaces as an unexpected EOF.
+	return urlErr.Err == io.EOF || urlErr.Err == io.ErrUnexpectedEOF
+}
+
+func isDialFailure(err error) bool {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return false
+	}
+
+	opErr, isOpError := urlErr.Err.(*net.OpError)
+	return isOpError && opErr.Op == "dial"
+}
+
+// Calculates the wait time before the next attempt. A Retry-After header sent by Keycloak or a
+// load balancer is respected if it asks for a longer wait, but never beyond MaxBackoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := p.MinBackoff << uint(attempt-1)
+
+	// The shift overflows for high attempt counts, which results in a negative or zero wait time.
+	if p.MinBackoff > 0 && (wait <= 0 || wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter && wait > 0 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	if resp != nil {
+		if retryAfter := parseRetryAfter(resp.Header.Get("Retry-After")); retryAfter > wait {
+			wait = retryAfter
+			if wait > p.MaxBackoff {
+				wait = p.MaxBackoff
+			}
+		}
+	}
+
+	return wait
+}
+
+// Retry-After is either a number of seconds or an HTTP date.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
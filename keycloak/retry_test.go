@@ -0,0 +1,142 @@
+package keycloak
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// Responds with the given status codes in order, followed by successful responses.
+func newFlakyServer(statusCodes ...int) (*httptest.Server, *int) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(statusCodes) {
+			w.WriteHeader(statusCodes[requests-1])
+			return
+		}
+
+		if r.Method == "POST" {
+			w.Header().Set("Location", "http://"+r.Host+r.URL.Path+"/test")
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		fmt.Fprint(w, `{"id":"master","realm":"master","enabled":true}`)
+	}))
+
+	return server, &requests
+}
+
+func newRetryingClient(server *httptest.Server) *KeycloakClient {
+	c, _ := NewClientWithToken("token", server.URL, WithBasePath(""), WithRetryPolicy(RetryPolicy{MaxAttempts: 3}))
+	return c
+}
+
+func TestTransientFailuresAreRetried(t *testing.T) {
+	server, requests := newFlakyServer(http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer server.Close()
+
+	if _, err := newRetryingClient(server).GetRealm("master"); err != nil {
+		t.Fatalf("Request was not retried: %s", err)
+	}
+
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestPostIsNotRetriedIfItMayHaveBeenProcessed(t *testing.T) {
+	server, requests := newFlakyServer(http.StatusBadGateway)
+	defer server.Close()
+
+	if err := newRetryingClient(server).AddRealmRolesToUser("master", "user", nil); err == nil {
+		t.Fatalf("Expected the failed POST to be reported")
+	}
+
+	if *requests != 1 {
+		t.Errorf("Expected 1 request, got %d", *requests)
+	}
+}
+
+func TestCreateIsResolvedIfAnEarlierAttemptCreatedTheResource(t *testing.T) {
+	server, requests := newFlakyServer(http.StatusBadGateway, http.StatusConflict)
+	defer server.Close()
+
+	realm, err := newRetryingClient(server).CreateRealm(&Realm{Realm: "master"})
+	if err != nil {
+		t.Fatalf("Conflict after an uncertain failure was not resolved: %s", err)
+	}
+
+	if realm.Id != "master" {
+		t.Errorf("Expected the existing realm to be returned, got %q", realm.Id)
+	}
+
+	// Two attempts to create the realm, followed by reading the existing realm
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestCreateConflictIsReportedWithoutEarlierAttempt(t *testing.T) {
+	server, requests := newFlakyServer(http.StatusConflict)
+	defer server.Close()
+
+	if _, err := newRetryingClient(server).CreateRealm(&Realm{Realm: "master"}); !IsConflict(err) {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+
+	if *requests != 1 {
+		t.Errorf("Expected 1 request, got %d", *requests)
+	}
+}
+
+func TestPostIsRetriedIfItWasNotProcessed(t *testing.T) {
+	server, requests := newFlakyServer(http.StatusServiceUnavailable)
+	defer server.Close()
+
+	if _, err := newRetryingClient(server).CreateRealm(&Realm{Realm: "test"}); err != nil {
+		t.Fatalf("POST was not retried: %s", err)
+	}
+
+	// Two attempts to create the realm, followed by reading the created realm
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 30 * time.Second}
+
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		attempt    int
+		retryAfter string
+		expected   time.Duration
+	}{
+		{"first retry", policy, 1, "", time.Second},
+		{"doubles on every attempt", policy, 3, "", 4 * time.Second},
+		{"capped at max backoff", policy, 6, "", 30 * time.Second},
+		{"shift overflow", policy, 40, "", 30 * time.Second},
+		{"shift beyond the width of a duration", policy, 100, "", 30 * time.Second},
+		{"no min backoff", RetryPolicy{MaxBackoff: 30 * time.Second}, 3, "", 0},
+		{"longer retry after", policy, 1, "5", 5 * time.Second},
+		{"shorter retry after", policy, 3, "1", 4 * time.Second},
+		{"retry after capped at max backoff", policy, 1, "120", 30 * time.Second},
+		{"retry after without min backoff", RetryPolicy{MaxBackoff: 30 * time.Second}, 1, "5", 5 * time.Second},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if test.retryAfter != "" {
+			resp.Header.Set("Retry-After", test.retryAfter)
+		}
+
+		if wait := test.policy.backoff(test.attempt, resp); wait != test.expected {
+			t.Errorf("%s: expected %s, got %s", test.name, test.expected, wait)
+		}
+	}
+}
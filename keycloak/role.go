@@ -1,11 +1,20 @@
 package keycloak
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 type RoleRepresentation struct {
 	Id          string `json:"id"`
 	Description string `json:"description"`
 	Name        string `json:"name"`
+	Composite   bool   `json:"composite"`
+	ClientRole  bool   `json:"clientRole,omitempty"`
+	ContainerId string `json:"containerId,omitempty"`
+
+	// A nil map is sent as null and leaves existing attributes untouched, an empty map removes them.
+	Attributes map[string][]string `json:"attributes"`
 }
 
 type CompositeRoleReference struct {
@@ -13,9 +22,14 @@ type CompositeRoleReference struct {
 }
 
 const (
-	clientRolesUri           = "%s/auth/admin/realms/%s/clients/%s/roles"
-	clientRoleUri            = "%s/auth/admin/realms/%s/clients/%s/roles/%s"
-	clientRolesCompositesUri = "%s/auth/admin/realms/%s/clients/%s/roles/%s/composites"
+	clientRolesUri           = "%s/admin/realms/%s/clients/%s/roles"
+	clientRoleUri            = "%s/admin/realms/%s/clients/%s/roles/%s"
+	clientRolesCompositesUri = "%s/admin/realms/%s/clients/%s/roles/%s/composites"
+
+	realmRolesUri         = "%s/admin/realms/%s/roles"
+	realmRoleUri          = "%s/admin/realms/%s/roles/%s"
+	roleByIdUri           = "%s/admin/realms/%s/roles-by-id/%s"
+	roleByIdCompositesUri = "%s/admin/realms/%s/roles-by-id/%s/composites"
 )
 
 func (c *KeycloakClient) GetClientRole(clientId string, realm string, roleName string) (*RoleRepresentation, error) {
@@ -28,7 +42,10 @@ func (c *KeycloakClient) GetClientRole(clientId string, realm string, roleName s
 func (c *KeycloakClient) CreateClientRole(clientId string, realm string, representation *RoleRepresentation) (*RoleRepresentation, error) {
 	url := fmt.Sprintf(clientRolesUri, c.url, realm, clientId)
 
-	_, err := c.post(url, representation)
+	// The location is not used, as created roles are looked up by name.
+	_, err := c.create(url, representation, func() (string, error) {
+		return "", nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +94,70 @@ func (c *KeycloakClient) RemoveRolesFromCompositeRole(clientId string, realm str
 	return err
 }
 
+// Realm roles are looked up by ID rather than by name so that they can be renamed.
+func (c *KeycloakClient) GetRealmRole(realm string, id string) (*RoleRepresentation, error) {
+	var role RoleRepresentation
+	roleUrl := fmt.Sprintf(roleByIdUri, c.url, realm, id)
+	err := c.get(roleUrl, &role)
+	return &role, err
+}
+
+func (c *KeycloakClient) GetRealmRoleByName(realm string, name string) (*RoleRepresentation, error) {
+	var role RoleRepresentation
+	roleUrl := fmt.Sprintf(realmRoleUri, c.url, realm, url.PathEscape(name))
+	err := c.get(roleUrl, &role)
+	return &role, err
+}
+
+func (c *KeycloakClient) CreateRealmRole(realm string, representation *RoleRepresentation) (*RoleRepresentation, error) {
+	rolesUrl := fmt.Sprintf(realmRolesUri, c.url, realm)
+
+	_, err := c.create(rolesUrl, representation, func() (string, error) {
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Keycloak returns the role name rather than its ID as the location of the created role.
+	return c.GetRealmRoleByName(realm, representation.Name)
+}
+
+func (c *KeycloakClient) UpdateRealmRole(realm string, representation *RoleRepresentation) error {
+	roleUrl := fmt.Sprintf(roleByIdUri, c.url, realm, representation.Id)
+	return c.put(roleUrl, representation)
+}
+
+func (c *KeycloakClient) DeleteRealmRole(realm string, id string) error {
+	roleUrl := fmt.Sprintf(roleByIdUri, c.url, realm, id)
+	return c.delete(roleUrl, nil)
+}
+
+// Returns the IDs of all realm and client roles that the given role is composed of.
+func (c *KeycloakClient) GetRealmRoleComposites(realm string, id string) ([]string, error) {
+	var roles []CompositeRoleReference
+	compositesUrl := fmt.Sprintf(roleByIdCompositesUri, c.url, realm, id)
+	err := c.get(compositesUrl, &roles)
+
+	compositeRoleIds := []string{}
+	for _, value := range roles {
+		compositeRoleIds = append(compositeRoleIds, value.Id)
+	}
+
+	return compositeRoleIds, err
+}
+
+func (c *KeycloakClient) AddRolesToRealmRole(realm string, id string, roleIds []string) error {
+	compositesUrl := fmt.Sprintf(roleByIdCompositesUri, c.url, realm, id)
+	_, err := c.post(compositesUrl, toCompositeRoleRepresentation(roleIds))
+	return err
+}
+
+func (c *KeycloakClient) RemoveRolesFromRealmRole(realm string, id string, roleIds []string) error {
+	compositesUrl := fmt.Sprintf(roleByIdCompositesUri, c.url, realm, id)
+	return c.delete(compositesUrl, toCompositeRoleRepresentation(roleIds))
+}
+
 func toCompositeRoleRepresentation(roleIds []string) []*CompositeRoleReference {
 	var roles []*CompositeRoleReference
 	for _, value := range roleIds {
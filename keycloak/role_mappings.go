@@ -11,9 +11,9 @@ type Role struct {
 }
 
 const (
-	rolesUri          = "%s/auth/admin/realms/%s/users/%s/role-mappings/%s"
-	availableRolesUri = "%s/auth/admin/realms/%s/users/%s/role-mappings/%s/available"
-	compositeRolesUri = "%s/auth/admin/realms/%s/users/%s/role-mappings/%s/composite"
+	rolesUri          = "%s/admin/realms/%s/users/%s/role-mappings/%s"
+	availableRolesUri = "%s/admin/realms/%s/users/%s/role-mappings/%s/available"
+	compositeRolesUri = "%s/admin/realms/%s/users/%s/role-mappings/%s/composite"
 )
 
 // Attempt to look up available roles for a given user ID
@@ -48,7 +48,7 @@ func (c *KeycloakClient) FindRoleForUser(roles []Role, roleIdentifier string) (*
 	}
 
 	if role.Id == "" {
-		return nil, fmt.Errorf("Role %s not found", roleIdentifier)
+		return nil, &NotFoundError{Message: fmt.Sprintf("Role %s not found", roleIdentifier)}
 	}
 
 	return &role, nil
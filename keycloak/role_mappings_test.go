@@ -0,0 +1,15 @@
+package keycloak
+
+import "testing"
+
+func TestRoleMissingFromMappingsIsNotFound(t *testing.T) {
+	c := &KeycloakClient{}
+
+	if _, err := c.FindRoleForUser([]Role{{Id: "1", Name: "admin"}}, "reader"); !IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	if role, err := c.FindRoleForUser([]Role{{Id: "1", Name: "admin"}}, "admin"); err != nil || role.Id != "1" {
+		t.Errorf("Expected the role to be found, got %v, %v", role, err)
+	}
+}
@@ -0,0 +1,96 @@
+package keycloak
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Settings for the HTTP connections made to Keycloak. Certificates and keys are PEM encoded.
+type TransportConfig struct {
+	RootCaCertificate     string
+	TlsClientCertificate  string
+	TlsClientKey          string
+	TlsInsecureSkipVerify bool
+
+	// Timeout for a complete request, including reading the response. Zero means no timeout.
+	Timeout time.Duration
+
+	// Proxy for all requests. If empty, the standard HTTP_PROXY / HTTPS_PROXY / NO_PROXY
+	// environment variables are used.
+	ProxyUrl string
+}
+
+// Options that can be passed when creating a KeycloakClient.
+type ClientOption func(*KeycloakClient)
+
+// Use the given HTTP client for all requests to Keycloak instead of http.DefaultClient.
+func WithHttpClient(httpClient *http.Client) ClientOption {
+	return func(c *KeycloakClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// Serve the Keycloak API from the given base path instead of detecting it. Keycloak distributions
+// based on WildFly use '/auth', while the Quarkus distribution serves from '/' by default.
+func WithBasePath(basePath string) ClientOption {
+	return func(c *KeycloakClient) {
+		c.basePath = strings.TrimRight(basePath, "/")
+		if c.basePath != "" && !strings.HasPrefix(c.basePath, "/") {
+			c.basePath = "/" + c.basePath
+		}
+
+		c.basePathConfigured = true
+	}
+}
+
+// Creates an HTTP client for the given transport settings.
+func NewHttpClient(config *TransportConfig) (*http.Client, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.TlsInsecureSkipVerify,
+	}
+
+	if config.RootCaCertificate != "" {
+		// Private CAs are trusted in addition to the system roots, which may be unavailable on some platforms.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM([]byte(config.RootCaCertificate)) {
+			return nil, fmt.Errorf("Could not parse root CA certificate: no PEM encoded certificates found")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.TlsClientCertificate != "" || config.TlsClientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(config.TlsClientCertificate), []byte(config.TlsClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("Could not load TLS client certificate: %s", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyUrl != "" {
+		proxyUrl, err := url.Parse(config.ProxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse proxy URL: %s", err)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+	}, nil
+}
@@ -1,6 +1,10 @@
 package keycloak
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
 
 type User struct {
 	Id              string   `json:"id"`
@@ -19,13 +23,15 @@ type User struct {
 }
 
 const (
-	userUri  = "%s/auth/admin/realms/%s/users/%s"
-	userList = "%s/auth/admin/realms/%s/users"
+	userUri  = "%s/admin/realms/%s/users/%s"
+	userList = "%s/admin/realms/%s/users"
 )
 
 func (c *KeycloakClient) AddUser(user *User, realm string) (*User, error) {
 	url := fmt.Sprintf(userList, c.url, realm)
-	userLocation, err := c.post(url, *user)
+	userLocation, err := c.create(url, *user, func() (string, error) {
+		return c.userLocation(user.Username, realm)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +42,24 @@ func (c *KeycloakClient) AddUser(user *User, realm string) (*User, error) {
 	return &createdUser, err
 }
 
+// Looks up the location of a user by username. Keycloak matches usernames by substring and stores them in
+// lower case, so the results are filtered for the exact username.
+func (c *KeycloakClient) userLocation(username string, realm string) (string, error) {
+	var users []User
+	err := c.get(fmt.Sprintf(userList, c.url, realm)+"?username="+url.QueryEscape(username), &users)
+	if err != nil {
+		return "", err
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user.Username, username) {
+			return fmt.Sprintf(userUri, c.url, realm, user.Id), nil
+		}
+	}
+
+	return "", fmt.Errorf("user %s was not found in realm %s", username, realm)
+}
+
 // Attempt to look up user by given user ID
 func (c *KeycloakClient) GetUser(userId string, realm string) (*User, error) {
 	url := fmt.Sprintf(userUri, c.url, realm, userId)
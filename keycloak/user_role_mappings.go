@@ -0,0 +1,43 @@
+package keycloak
+
+import (
+	"fmt"
+)
+
+const (
+	userRoleMappingsUri       = "%s/admin/realms/%s/users/%s/role-mappings"
+	userRealmRoleMappingsUri  = "%s/admin/realms/%s/users/%s/role-mappings/realm"
+	userClientRoleMappingsUri = "%s/admin/realms/%s/users/%s/role-mappings/clients/%s"
+)
+
+func (c *KeycloakClient) GetUserRoleMappings(realm string, userId string) (*RoleMappings, error) {
+	url := fmt.Sprintf(userRoleMappingsUri, c.url, realm, userId)
+
+	var mappings RoleMappings
+	err := c.get(url, &mappings)
+
+	return &mappings, err
+}
+
+func (c *KeycloakClient) AddRealmRolesToUser(realm string, userId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(userRealmRoleMappingsUri, c.url, realm, userId)
+	_, err := c.post(url, roles)
+	return err
+}
+
+func (c *KeycloakClient) RemoveRealmRolesFromUser(realm string, userId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(userRealmRoleMappingsUri, c.url, realm, userId)
+	return c.delete(url, roles)
+}
+
+// The client ID is the internal ID of the client, not its human-readable client ID.
+func (c *KeycloakClient) AddClientRolesToUser(realm string, userId string, clientId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(userClientRoleMappingsUri, c.url, realm, userId, clientId)
+	_, err := c.post(url, roles)
+	return err
+}
+
+func (c *KeycloakClient) RemoveClientRolesFromUser(realm string, userId string, clientId string, roles []RoleRepresentation) error {
+	url := fmt.Sprintf(userClientRoleMappingsUri, c.url, realm, userId, clientId)
+	return c.delete(url, roles)
+}
@@ -0,0 +1,197 @@
+// This file provides the shared implementation for managing which client scopes are assigned to the realm
+// (i.e. to new clients) and to individual clients. Assigned scopes are referenced by name.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Manages all client scopes of one assignment type in a realm, so only one of these resources should exist
+// per realm and assignment type. Changes only affect clients that are created afterwards.
+func realmClientScopesResource(assignment keycloak.ClientScopeAssignment) *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRealmClientScopesRead(assignment, d, m)
+		},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			d.SetId(realm(d))
+			return resourceRealmClientScopesUpdate(assignment, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRealmClientScopesUpdate(assignment, d, m)
+		},
+		Delete: func(d *schema.ResourceData, m interface{}) error {
+			return resourceRealmClientScopesDelete(assignment, d, m)
+		},
+
+		// Realm client scope assignments are importable by realm name.
+		Importer: &schema.ResourceImporter{
+			State: importRealmClientScopesHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Names of the assigned client scopes
+			"client_scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func resourceRealmDefaultClientScopes() *schema.Resource {
+	return realmClientScopesResource(keycloak.DefaultClientScopes)
+}
+
+func resourceRealmOptionalClientScopes() *schema.Resource {
+	return realmClientScopesResource(keycloak.OptionalClientScopes)
+}
+
+func importRealmClientScopesHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRealmClientScopesRead(assignment keycloak.ClientScopeAssignment, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	scopes, err := apiClient.GetRealmClientScopes(d.Id(), assignment)
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("realm", d.Id())
+	d.Set("client_scopes", clientScopeNames(scopes))
+	return nil
+}
+
+func resourceRealmClientScopesUpdate(assignment keycloak.ClientScopeAssignment, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	realm := d.Id()
+
+	current, err := apiClient.GetRealmClientScopes(realm, assignment)
+	if err != nil {
+		return err
+	}
+
+	err = applyClientScopeAssignments(apiClient, realm, current, getOptionalStringSet(d, "client_scopes"),
+		func(scopeId string) error { return apiClient.AddRealmClientScope(realm, assignment, scopeId) },
+		func(scopeId string) error { return apiClient.RemoveRealmClientScope(realm, assignment, scopeId) },
+	)
+	if err != nil {
+		return err
+	}
+
+	return resourceRealmClientScopesRead(assignment, d, m)
+}
+
+func resourceRealmClientScopesDelete(assignment keycloak.ClientScopeAssignment, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	realm := d.Id()
+
+	current, err := apiClient.GetRealmClientScopes(realm, assignment)
+	if err != nil {
+		return err
+	}
+
+	configured := getOptionalStringSet(d, "client_scopes")
+	for _, scope := range current {
+		if contains(configured, scope.Name) {
+			err = apiClient.RemoveRealmClientScope(realm, assignment, scope.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Assigns and unassigns client scopes so that exactly the scopes with the desired names are assigned.
+func applyClientScopeAssignments(apiClient *keycloak.KeycloakClient, realm string, current []keycloak.ClientScope,
+	desired []string, add func(scopeId string) error, remove func(scopeId string) error) error {
+
+	err := removeClientScopeAssignments(current, desired, remove)
+	if err != nil {
+		return err
+	}
+
+	return addClientScopeAssignments(apiClient, realm, current, desired, add)
+}
+
+// Unassigns the currently assigned client scopes that are not desired.
+func removeClientScopeAssignments(current []keycloak.ClientScope, desired []string, remove func(scopeId string) error) error {
+	for _, scope := range current {
+		if !contains(desired, scope.Name) {
+			err := remove(scope.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Assigns the desired client scopes that are not currently assigned.
+func addClientScopeAssignments(apiClient *keycloak.KeycloakClient, realm string, current []keycloak.ClientScope,
+	desired []string, add func(scopeId string) error) error {
+
+	currentNames := clientScopeNames(current)
+	missing := []string{}
+	for _, name := range desired {
+		if !contains(currentNames, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// Scopes can only be assigned by ID, so the IDs are looked up in the list of all scopes in the realm.
+	all, err := apiClient.GetClientScopes(realm)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range missing {
+		scopeId := ""
+		for _, scope := range all {
+			if scope.Name == name {
+				scopeId = scope.Id
+			}
+		}
+
+		if scopeId == "" {
+			return fmt.Errorf("Client scope '%s' does not exist in realm '%s'", name, realm)
+		}
+
+		err = add(scopeId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func clientScopeNames(scopes []keycloak.ClientScope) []string {
+	names := []string{}
+	for _, scope := range scopes {
+		names = append(names, scope.Name)
+	}
+	return names
+}
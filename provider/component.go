@@ -0,0 +1,42 @@
+// This file provides helpers for resources that are implemented as Keycloak components, such as the LDAP user
+// federation provider and its mappers.
+
+package provider
+
+import (
+	"strings"
+)
+
+// Component config values are lists, but the settings managed by this provider are all single values.
+func toComponentConfig(config map[string]string) map[string][]string {
+	componentConfig := map[string][]string{}
+	for key, value := range config {
+		componentConfig[key] = []string{value}
+	}
+	return componentConfig
+}
+
+func fromComponentConfig(componentConfig map[string][]string) map[string]string {
+	config := map[string]string{}
+	for key, values := range componentConfig {
+		if len(values) > 0 {
+			config[key] = values[0]
+		}
+	}
+	return config
+}
+
+// Some settings, e.g. LDAP object classes, are stored as comma separated lists in a single value.
+func joinCommaSeparated(values []string) string {
+	return strings.Join(values, ", ")
+}
+
+func splitCommaSeparated(value string) []string {
+	values := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
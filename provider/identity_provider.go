@@ -0,0 +1,253 @@
+// This file provides the shared implementation of the identity provider resources. Every kind of identity
+// provider is exposed as its own resource with typed attributes that are translated into the provider's config.
+// The identity provider resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_identityproviderrepresentation
+
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+type identityProviderType struct {
+	// The Keycloak provider type, e.g. 'oidc'. If empty, the type is configured by the user through
+	// the 'provider_id' attribute.
+	providerId string
+
+	// Attributes specific to this kind of identity provider, in addition to the common ones.
+	schema map[string]*schema.Schema
+
+	toConfig   func(d *schema.ResourceData, config map[string]string)
+	fromConfig func(config map[string]string, d *schema.ResourceData)
+}
+
+func identityProviderResource(providerType identityProviderType) *schema.Resource {
+	resourceSchema := mergeSchemas(map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		// The alias identifies the identity provider and is part of its redirect URI.
+		"alias": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"display_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		// Whether email addresses provided by the identity provider are marked as verified
+		"trust_email": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"store_token": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		// Only allows linking existing accounts to the identity provider, not logging in with it
+		"link_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"hide_on_login_page": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"first_broker_login_flow_alias": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "first broker login",
+		},
+		"post_broker_login_flow_alias": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		// How user data is updated on subsequent logins: 'IMPORT' only imports it on the first login,
+		// 'FORCE' updates it on every login, 'LEGACY' keeps the behaviour of older Keycloak versions.
+		"sync_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "IMPORT",
+			ValidateFunc: validation.StringInSlice([]string{"IMPORT", "FORCE", "LEGACY"}, false),
+		},
+		// Additional config entries. Only the entries listed here are managed by Terraform.
+		"extra_config": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}, providerType.schema)
+
+	return &schema.Resource{
+		// API methods
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderRead(providerType, d, m)
+		},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderCreate(providerType, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderUpdate(providerType, d, m)
+		},
+		Delete: schema.DeleteFunc(resourceIdentityProviderDelete),
+
+		// Identity providers are importable as '${realm}.${alias}'.
+		Importer: &schema.ResourceImporter{
+			State: importIdentityProviderHelper,
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+func importIdentityProviderHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, alias, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(alias)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceIdentityProviderRead(providerType identityProviderType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	provider, err := apiClient.GetIdentityProvider(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("alias", provider.Alias)
+	d.Set("display_name", provider.DisplayName)
+	d.Set("enabled", provider.Enabled)
+	d.Set("trust_email", provider.TrustEmail)
+	d.Set("store_token", provider.StoreToken)
+	d.Set("link_only", provider.LinkOnly)
+	d.Set("first_broker_login_flow_alias", provider.FirstBrokerLoginFlowAlias)
+	d.Set("post_broker_login_flow_alias", provider.PostBrokerLoginFlowAlias)
+	d.Set("hide_on_login_page", parseConfigBool(provider.Config["hideOnLoginPage"]))
+	d.Set("sync_mode", provider.Config["syncMode"])
+
+	if providerType.providerId == "" {
+		d.Set("provider_id", provider.ProviderId)
+	}
+
+	// Only config entries that are already managed are read, so that Keycloak's defaults don't show up
+	// as changes.
+	extraConfig := map[string]string{}
+	for key := range getOptionalStringMap(d, "extra_config") {
+		if value, present := provider.Config[key]; present {
+			extraConfig[key] = value
+		}
+	}
+	d.Set("extra_config", extraConfig)
+
+	providerType.fromConfig(provider.Config, d)
+	return nil
+}
+
+func resourceIdentityProviderCreate(providerType identityProviderType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateIdentityProvider(realm(d), resourceDataToIdentityProvider(providerType, d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Alias)
+
+	return resourceIdentityProviderRead(providerType, d, m)
+}
+
+func resourceIdentityProviderUpdate(providerType identityProviderType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateIdentityProvider(realm(d), resourceDataToIdentityProvider(providerType, d))
+	if err != nil {
+		return err
+	}
+
+	return resourceIdentityProviderRead(providerType, d, m)
+}
+
+func resourceIdentityProviderDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteIdentityProvider(realm(d), d.Id())
+}
+
+func resourceDataToIdentityProvider(providerType identityProviderType, d *schema.ResourceData) *keycloak.IdentityProvider {
+	// Keycloak replaces the whole config on update, so all entries are sent.
+	config := getOptionalStringMap(d, "extra_config")
+	config["hideOnLoginPage"] = strconv.FormatBool(d.Get("hide_on_login_page").(bool))
+	config["syncMode"] = d.Get("sync_mode").(string)
+	providerType.toConfig(d, config)
+
+	provider := keycloak.IdentityProvider{
+		Alias:                     d.Get("alias").(string),
+		DisplayName:               d.Get("display_name").(string),
+		ProviderId:                providerType.providerId,
+		Enabled:                   d.Get("enabled").(bool),
+		TrustEmail:                d.Get("trust_email").(bool),
+		StoreToken:                d.Get("store_token").(bool),
+		LinkOnly:                  d.Get("link_only").(bool),
+		FirstBrokerLoginFlowAlias: d.Get("first_broker_login_flow_alias").(string),
+		PostBrokerLoginFlowAlias:  d.Get("post_broker_login_flow_alias").(string),
+		Config:                    config,
+	}
+
+	if provider.ProviderId == "" {
+		provider.ProviderId = d.Get("provider_id").(string)
+	}
+
+	return &provider
+}
+
+// Client credentials that are used to authenticate against OAuth based identity providers. Keycloak
+// does not return the secret, so it is only written.
+func identityProviderClientSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"client_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"client_secret": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		// Space separated scopes that are requested from the identity provider
+		"default_scopes": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func identityProviderClientToConfig(d *schema.ResourceData, config map[string]string) {
+	config["clientId"] = d.Get("client_id").(string)
+	config["clientSecret"] = d.Get("client_secret").(string)
+	config["defaultScope"] = d.Get("default_scopes").(string)
+}
+
+func identityProviderClientFromConfig(config map[string]string, d *schema.ResourceData) {
+	d.Set("client_id", config["clientId"])
+	d.Set("default_scopes", config["defaultScope"])
+}
@@ -0,0 +1,195 @@
+// This file provides the shared implementation of the identity provider mapper resources. Every mapper type
+// is exposed as its own resource with typed attributes that are translated into the mapper's config map.
+// The mapper resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_identityprovidermapperrepresentation
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+type identityProviderMapperType struct {
+	// Returns the Keycloak mapper type, e.g. 'hardcoded-role-idp-mapper'. Some mappers exist in variants
+	// for OpenID Connect and SAML, so the provider ID of the identity provider is passed in. If nil, the
+	// type is configured by the user through the 'identity_provider_mapper' attribute.
+	identityProviderMapper func(d *schema.ResourceData, providerId string) string
+
+	// Attributes specific to this mapper type, in addition to the common ones.
+	schema map[string]*schema.Schema
+
+	toConfig   func(d *schema.ResourceData, config map[string]string)
+	fromConfig func(config map[string]string, d *schema.ResourceData)
+}
+
+// Returns the same mapper type for every kind of identity provider.
+func fixedIdentityProviderMapper(mapper string) func(*schema.ResourceData, string) string {
+	return func(*schema.ResourceData, string) string {
+		return mapper
+	}
+}
+
+// Returns the SAML variant of a mapper for SAML identity providers and the OpenID Connect variant otherwise.
+func protocolIdentityProviderMapper(oidcMapper string, samlMapper string) func(*schema.ResourceData, string) string {
+	return func(_ *schema.ResourceData, providerId string) string {
+		if providerId == samlProtocol {
+			return samlMapper
+		}
+		return oidcMapper
+	}
+}
+
+func identityProviderMapperResource(mapperType identityProviderMapperType) *schema.Resource {
+	resourceSchema := mergeSchemas(map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"identity_provider_alias": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// Overrides the sync mode of the identity provider for this mapper
+		"sync_mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "INHERIT",
+			ValidateFunc: validation.StringInSlice([]string{"INHERIT", "IMPORT", "FORCE", "LEGACY"}, false),
+		},
+	}, mapperType.schema)
+
+	return &schema.Resource{
+		// API methods
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperRead(mapperType, d, m)
+		},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperCreate(mapperType, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceIdentityProviderMapperUpdate(mapperType, d, m)
+		},
+		Delete: schema.DeleteFunc(resourceIdentityProviderMapperDelete),
+
+		// Identity provider mappers are importable as '${realm}.${alias}.${mapper_id}'.
+		Importer: &schema.ResourceImporter{
+			State: importIdentityProviderMapperHelper,
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+func importIdentityProviderMapperHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 2)
+	if !ok {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.${identity_provider_alias}.${mapper_id}'")
+	}
+
+	d.Set("realm", realm)
+	d.Set("identity_provider_alias", ids[0])
+	d.SetId(ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func identityProviderAlias(d *schema.ResourceData) string {
+	return d.Get("identity_provider_alias").(string)
+}
+
+func resourceIdentityProviderMapperRead(mapperType identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	mapper, err := apiClient.GetIdentityProviderMapper(realm(d), identityProviderAlias(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("name", mapper.Name)
+	d.Set("sync_mode", mapper.Config["syncMode"])
+
+	if mapperType.identityProviderMapper == nil {
+		d.Set("identity_provider_mapper", mapper.IdentityProviderMapper)
+	}
+
+	mapperType.fromConfig(mapper.Config, d)
+	return nil
+}
+
+func resourceIdentityProviderMapperCreate(mapperType identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	mapper, err := resourceDataToIdentityProviderMapper(mapperType, d, apiClient)
+	if err != nil {
+		return err
+	}
+
+	created, err := apiClient.CreateIdentityProviderMapper(realm(d), mapper)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceIdentityProviderMapperRead(mapperType, d, m)
+}
+
+func resourceIdentityProviderMapperUpdate(mapperType identityProviderMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	mapper, err := resourceDataToIdentityProviderMapper(mapperType, d, apiClient)
+	if err != nil {
+		return err
+	}
+
+	err = apiClient.UpdateIdentityProviderMapper(realm(d), mapper)
+	if err != nil {
+		return err
+	}
+
+	return resourceIdentityProviderMapperRead(mapperType, d, m)
+}
+
+func resourceIdentityProviderMapperDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteIdentityProviderMapper(realm(d), identityProviderAlias(d), d.Id())
+}
+
+func resourceDataToIdentityProviderMapper(mapperType identityProviderMapperType, d *schema.ResourceData, apiClient *keycloak.KeycloakClient) (*keycloak.IdentityProviderMapper, error) {
+	provider, err := apiClient.GetIdentityProvider(realm(d), identityProviderAlias(d))
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]string{
+		"syncMode": d.Get("sync_mode").(string),
+	}
+	mapperType.toConfig(d, config)
+
+	mapper := keycloak.IdentityProviderMapper{
+		Name:                  d.Get("name").(string),
+		IdentityProviderAlias: identityProviderAlias(d),
+		Config:                config,
+	}
+
+	if mapperType.identityProviderMapper != nil {
+		mapper.IdentityProviderMapper = mapperType.identityProviderMapper(d, provider.ProviderId)
+	} else {
+		mapper.IdentityProviderMapper = d.Get("identity_provider_mapper").(string)
+	}
+
+	if !d.IsNewResource() {
+		mapper.Id = d.Id()
+	}
+
+	return &mapper, nil
+}
@@ -0,0 +1,190 @@
+// This file provides the shared implementation of the LDAP mapper resources. LDAP mappers are components
+// that belong to an LDAP user federation provider, every mapper type is exposed as its own resource.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+const ldapMapperProviderType = "org.keycloak.storage.ldap.mappers.LDAPStorageMapper"
+
+type ldapMapperType struct {
+	// The Keycloak mapper type, e.g. 'user-attribute-ldap-mapper'
+	providerId string
+
+	// Attributes specific to this mapper type, in addition to the common ones.
+	schema map[string]*schema.Schema
+
+	toConfig   func(d *schema.ResourceData) map[string]string
+	fromConfig func(config map[string]string, d *schema.ResourceData)
+}
+
+func ldapMapperResource(mapperType ldapMapperType) *schema.Resource {
+	resourceSchema := mergeSchemas(map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		// The 'id' attribute of keycloak_ldap_user_federation
+		"ldap_user_federation_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}, mapperType.schema)
+
+	return &schema.Resource{
+		// API methods
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceLdapMapperRead(mapperType, d, m)
+		},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceLdapMapperCreate(mapperType, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceLdapMapperUpdate(mapperType, d, m)
+		},
+		Delete: schema.DeleteFunc(resourceLdapMapperDelete),
+
+		// LDAP mappers are importable as '${realm}.${ldap_user_federation_id}.${mapper_id}'.
+		Importer: &schema.ResourceImporter{
+			State: importLdapMapperHelper,
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+func importLdapMapperHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 2)
+	if !ok {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.${ldap_user_federation_id}.${mapper_id}'")
+	}
+
+	d.Set("realm", realm)
+	d.Set("ldap_user_federation_id", ids[0])
+	d.SetId(ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceLdapMapperRead(mapperType ldapMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	component, err := apiClient.GetComponent(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("name", component.Name)
+	d.Set("ldap_user_federation_id", component.ParentId)
+
+	mapperType.fromConfig(fromComponentConfig(component.Config), d)
+	return nil
+}
+
+func resourceLdapMapperCreate(mapperType ldapMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateComponent(realm(d), resourceDataToLdapMapper(mapperType, d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceLdapMapperRead(mapperType, d, m)
+}
+
+func resourceLdapMapperUpdate(mapperType ldapMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateComponent(realm(d), resourceDataToLdapMapper(mapperType, d))
+	if err != nil {
+		return err
+	}
+
+	return resourceLdapMapperRead(mapperType, d, m)
+}
+
+func resourceLdapMapperDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteComponent(realm(d), d.Id())
+}
+
+func resourceDataToLdapMapper(mapperType ldapMapperType, d *schema.ResourceData) *keycloak.Component {
+	c := keycloak.Component{
+		Name:         d.Get("name").(string),
+		ProviderId:   mapperType.providerId,
+		ProviderType: ldapMapperProviderType,
+		ParentId:     d.Get("ldap_user_federation_id").(string),
+		Config:       toComponentConfig(mapperType.toConfig(d)),
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return &c
+}
+
+// The group and role mappers share the settings for looking up LDAP entries and their members. Their strategies
+// for retrieving the memberships of a user differ and are part of each mapper's own schema.
+func ldapMembershipSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"membership_ldap_attribute": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// Whether the membership attribute contains the full DN or the UID of members
+		"membership_attribute_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "DN",
+			ValidateFunc: validation.StringInSlice([]string{"DN", "UID"}, false),
+		},
+		"membership_user_ldap_attribute": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// 'LDAP_ONLY' writes memberships to LDAP, 'IMPORT' imports them once, 'READ_ONLY' reads them from LDAP.
+		"mode": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "READ_ONLY",
+			ValidateFunc: validation.StringInSlice([]string{"READ_ONLY", "LDAP_ONLY", "IMPORT"}, false),
+		},
+		"memberof_ldap_attribute": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "memberOf",
+		},
+	}
+}
+
+func ldapMembershipToConfig(d *schema.ResourceData, config map[string]string) map[string]string {
+	config["membership.ldap.attribute"] = d.Get("membership_ldap_attribute").(string)
+	config["membership.attribute.type"] = d.Get("membership_attribute_type").(string)
+	config["membership.user.ldap.attribute"] = d.Get("membership_user_ldap_attribute").(string)
+	config["mode"] = d.Get("mode").(string)
+	config["memberof.ldap.attribute"] = d.Get("memberof_ldap_attribute").(string)
+	return config
+}
+
+func ldapMembershipFromConfig(config map[string]string, d *schema.ResourceData) {
+	d.Set("membership_ldap_attribute", config["membership.ldap.attribute"])
+	d.Set("membership_attribute_type", config["membership.attribute.type"])
+	d.Set("membership_user_ldap_attribute", config["membership.user.ldap.attribute"])
+	d.Set("mode", config["mode"])
+	d.Set("memberof_ldap_attribute", config["memberof.ldap.attribute"])
+}
@@ -0,0 +1,240 @@
+// This file provides the shared implementation of the protocol mapper resources. Every mapper type is
+// exposed as its own resource with typed attributes that are translated into the mapper's config map.
+// The protocol mapper resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_protocolmapperrepresentation
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+const openIdConnectProtocol = "openid-connect"
+
+type protocolMapperType struct {
+	protocol string
+
+	// The Keycloak mapper type, e.g. 'oidc-audience-mapper'. If empty, the type is configured by the
+	// user through the 'protocol_mapper' attribute.
+	protocolMapper string
+
+	// Attributes specific to this mapper type, in addition to the common ones.
+	schema map[string]*schema.Schema
+
+	toConfig   func(d *schema.ResourceData) map[string]string
+	fromConfig func(config map[string]string, d *schema.ResourceData)
+
+	// Checks the combination of attributes before the mapper is created or updated, if set.
+	validate func(d *schema.ResourceData) error
+}
+
+func protocolMapperResource(mapperType protocolMapperType) *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		// Mappers are attached to either a client or a client scope, referenced by their IDs.
+		"client_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"client_scope_id"},
+		},
+		"client_scope_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ForceNew:      true,
+			ConflictsWith: []string{"client_id"},
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+
+	for key, attribute := range mapperType.schema {
+		resourceSchema[key] = attribute
+	}
+
+	return &schema.Resource{
+		// API methods
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			return resourceProtocolMapperRead(mapperType, d, m)
+		},
+		Create: func(d *schema.ResourceData, m interface{}) error {
+			return resourceProtocolMapperCreate(mapperType, d, m)
+		},
+		Update: func(d *schema.ResourceData, m interface{}) error {
+			return resourceProtocolMapperUpdate(mapperType, d, m)
+		},
+		Delete: schema.DeleteFunc(resourceProtocolMapperDelete),
+
+		// Protocol mappers are importable as '${realm}.client.${client_id}.${mapper_id}'
+		// or '${realm}.client-scope.${client_scope_id}.${mapper_id}'.
+		Importer: &schema.ResourceImporter{
+			State: importProtocolMapperHelper,
+		},
+
+		Schema: resourceSchema,
+	}
+}
+
+func importProtocolMapperHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 3)
+	if !ok || (ids[0] != "client" && ids[0] != "client-scope") {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.client.${client_id}.${mapper_id}' or '${realm}.client-scope.${client_scope_id}.${mapper_id}'")
+	}
+
+	d.Set("realm", realm)
+	if ids[0] == "client" {
+		d.Set("client_id", ids[1])
+	} else {
+		d.Set("client_scope_id", ids[1])
+	}
+	d.SetId(ids[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceProtocolMapperRead(mapperType protocolMapperType, d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	mapper, err := apiClient.GetProtocolMapper(realm(d), clientId(d), clientScopeId(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("name", mapper.Name)
+	if mapperType.protocolMapper == "" {
+		d.Set("protocol_mapper", mapper.ProtocolMapper)
+	}
+
+	mapperType.fromConfig(mapper.Config, d)
+	return nil
+}
+
+func resourceProtocolMapperCreate(mapperType protocolMapperType, d *schema.ResourceData, m interface{}) error {
+	if clientId(d) == "" && clientScopeId(d) == "" {
+		return fmt.Errorf("One of client_id or client_scope_id must be set")
+	}
+
+	if mapperType.validate != nil {
+		if err := mapperType.validate(d); err != nil {
+			return err
+		}
+	}
+
+	apiClient := m.(*keycloak.KeycloakClient)
+	mapper := resourceDataToProtocolMapper(mapperType, d)
+
+	created, err := apiClient.CreateProtocolMapper(realm(d), clientId(d), clientScopeId(d), mapper)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceProtocolMapperRead(mapperType, d, m)
+}
+
+func resourceProtocolMapperUpdate(mapperType protocolMapperType, d *schema.ResourceData, m interface{}) error {
+	if mapperType.validate != nil {
+		if err := mapperType.validate(d); err != nil {
+			return err
+		}
+	}
+
+	apiClient := m.(*keycloak.KeycloakClient)
+	mapper := resourceDataToProtocolMapper(mapperType, d)
+
+	err := apiClient.UpdateProtocolMapper(realm(d), clientId(d), clientScopeId(d), mapper)
+	if err != nil {
+		return err
+	}
+
+	return resourceProtocolMapperRead(mapperType, d, m)
+}
+
+func resourceProtocolMapperDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteProtocolMapper(realm(d), clientId(d), clientScopeId(d), d.Id())
+}
+
+func resourceDataToProtocolMapper(mapperType protocolMapperType, d *schema.ResourceData) *keycloak.ProtocolMapper {
+	mapper := keycloak.ProtocolMapper{
+		Name:           d.Get("name").(string),
+		Protocol:       mapperType.protocol,
+		ProtocolMapper: mapperType.protocolMapper,
+		Config:         mapperType.toConfig(d),
+	}
+
+	if mapper.ProtocolMapper == "" {
+		mapper.ProtocolMapper = d.Get("protocol_mapper").(string)
+	}
+
+	if !d.IsNewResource() {
+		mapper.Id = d.Id()
+	}
+
+	return &mapper
+}
+
+func clientScopeId(d *schema.ResourceData) string {
+	return d.Get("client_scope_id").(string)
+}
+
+// Most OpenID Connect mappers can add their claim to each of the issued tokens separately.
+func tokenClaimSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"add_to_id_token": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"add_to_access_token": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"add_to_userinfo": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+	}
+}
+
+func tokenClaimsToConfig(d *schema.ResourceData, config map[string]string) map[string]string {
+	config["id.token.claim"] = strconv.FormatBool(d.Get("add_to_id_token").(bool))
+	config["access.token.claim"] = strconv.FormatBool(d.Get("add_to_access_token").(bool))
+	config["userinfo.token.claim"] = strconv.FormatBool(d.Get("add_to_userinfo").(bool))
+	return config
+}
+
+func tokenClaimsFromConfig(config map[string]string, d *schema.ResourceData) {
+	d.Set("add_to_id_token", parseConfigBool(config["id.token.claim"]))
+	d.Set("add_to_access_token", parseConfigBool(config["access.token.claim"]))
+	d.Set("add_to_userinfo", parseConfigBool(config["userinfo.token.claim"]))
+}
+
+// Keycloak stores all mapper settings as strings and omits unset boolean settings.
+func parseConfigBool(value string) bool {
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+// Merges mapper type specific schema attributes with additional ones.
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for key, attribute := range s {
+			merged[key] = attribute
+		}
+	}
+	return merged
+}
@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/tazjin/terraform-provider-keycloak/keycloak"
 )
@@ -11,13 +15,58 @@ func Provider() terraform.ResourceProvider {
 		Schema:        keycloakProviderSchema(),
 		ConfigureFunc: schema.ConfigureFunc(keycloakProviderSetup),
 		ResourcesMap: map[string]*schema.Resource{
-			"keycloak_client":             resourceClient(),
-			"keycloak_client_role":        resourceClientRole(),
-			"keycloak_user_role_mapping":  resourceUserRoleMapping(),
-			"keycloak_realm":              resourceRealm(),
-			"keycloak_user":               resourceUser(),
-			"keycloak_group":              resourceGroup(),
-			"keycloak_user_group_mapping": resourceUserGroupMapping(),
+			"keycloak_client":                      resourceClient(),
+			"keycloak_client_secret_rotation":      resourceClientSecretRotation(),
+			"keycloak_client_service_account_role": resourceClientServiceAccountRole(),
+			"keycloak_client_role":                 resourceClientRole(),
+			"keycloak_realm_role":                  resourceRealmRole(),
+			"keycloak_user_role_mapping":           resourceUserRoleMapping(),
+			"keycloak_realm":                       resourceRealm(),
+			"keycloak_realm_events":                resourceRealmEvents(),
+			"keycloak_user":                        resourceUser(),
+			"keycloak_group":                       resourceGroup(),
+			"keycloak_group_role_mapping":          resourceGroupRoleMapping(),
+			"keycloak_user_group_mapping":          resourceUserGroupMapping(),
+
+			"keycloak_openid_protocol_mapper":                  resourceOpenIdProtocolMapper(),
+			"keycloak_openid_user_attribute_protocol_mapper":   resourceOpenIdUserAttributeProtocolMapper(),
+			"keycloak_openid_group_membership_protocol_mapper": resourceOpenIdGroupMembershipProtocolMapper(),
+			"keycloak_openid_audience_protocol_mapper":         resourceOpenIdAudienceProtocolMapper(),
+			"keycloak_openid_hardcoded_claim_protocol_mapper":  resourceOpenIdHardcodedClaimProtocolMapper(),
+			"keycloak_openid_full_name_protocol_mapper":        resourceOpenIdFullNameProtocolMapper(),
+
+			"keycloak_openid_client_scope":          resourceOpenIdClientScope(),
+			"keycloak_realm_default_client_scopes":  resourceRealmDefaultClientScopes(),
+			"keycloak_realm_optional_client_scopes": resourceRealmOptionalClientScopes(),
+
+			"keycloak_identity_provider":      resourceGenericIdentityProvider(),
+			"keycloak_oidc_identity_provider": resourceOidcIdentityProvider(),
+			"keycloak_saml_identity_provider": resourceSamlIdentityProvider(),
+
+			"keycloak_identity_provider_mapper":                            resourceIdentityProviderMapper(),
+			"keycloak_attribute_importer_identity_provider_mapper":         resourceAttributeImporterIdentityProviderMapper(),
+			"keycloak_hardcoded_role_identity_provider_mapper":             resourceHardcodedRoleIdentityProviderMapper(),
+			"keycloak_hardcoded_group_identity_provider_mapper":            resourceHardcodedGroupIdentityProviderMapper(),
+			"keycloak_claim_to_role_identity_provider_mapper":              resourceClaimToRoleIdentityProviderMapper(),
+			"keycloak_advanced_claim_to_group_identity_provider_mapper":    resourceAdvancedClaimToGroupIdentityProviderMapper(),
+			"keycloak_username_template_importer_identity_provider_mapper": resourceUsernameTemplateImporterIdentityProviderMapper(),
+
+			"keycloak_ldap_user_federation":                  resourceLdapUserFederation(),
+			"keycloak_ldap_user_attribute_mapper":            resourceLdapUserAttributeMapper(),
+			"keycloak_ldap_group_mapper":                     resourceLdapGroupMapper(),
+			"keycloak_ldap_role_mapper":                      resourceLdapRoleMapper(),
+			"keycloak_ldap_full_name_mapper":                 resourceLdapFullNameMapper(),
+			"keycloak_ldap_msad_user_account_control_mapper": resourceLdapMsadUserAccountControlMapper(),
+
+			"keycloak_authentication_flow":             resourceAuthenticationFlow(),
+			"keycloak_authentication_subflow":          resourceAuthenticationSubflow(),
+			"keycloak_authentication_execution":        resourceAuthenticationExecution(),
+			"keycloak_authentication_execution_config": resourceAuthenticationExecutionConfig(),
+			"keycloak_required_action":                 resourceRequiredAction(),
+
+			"keycloak_saml_client":                         resourceSamlClient(),
+			"keycloak_saml_user_attribute_protocol_mapper": resourceSamlUserAttributeProtocolMapper(),
+			"keycloak_saml_role_list_protocol_mapper":      resourceSamlRoleListProtocolMapper(),
 		},
 	}
 }
@@ -27,15 +76,43 @@ func Provider() terraform.ResourceProvider {
 func keycloakProviderSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"client_id": {
-			Required:    true,
+			Optional:    true,
 			Type:        schema.TypeString,
 			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ID", nil),
 		},
+		// Either a client secret (client credentials grant) or a username and password (password grant)
+		// must be configured. Confidential clients additionally need the secret for the password grant.
 		"client_secret": {
-			Required:    true,
+			Optional:    true,
+			Sensitive:   true,
 			Type:        schema.TypeString,
 			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_SECRET", nil),
 		},
+		"username": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_USER", nil),
+		},
+		"password": {
+			Optional:    true,
+			Sensitive:   true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_PASSWORD", nil),
+		},
+		// A pre-issued bearer token, used as-is without ever contacting the token endpoint.
+		"access_token": {
+			Optional:    true,
+			Sensitive:   true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_ACCESS_TOKEN", nil),
+		},
+		// A file containing a bearer token. The file is read again whenever the token expires or
+		// is rejected, so it can be rotated by an external process while Terraform is running.
+		"access_token_file": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_ACCESS_TOKEN_FILE", nil),
+		},
 		"api_base": {
 			Required:    true,
 			Type:        schema.TypeString,
@@ -46,16 +123,185 @@ func keycloakProviderSchema() map[string]*schema.Schema {
 			Type:        schema.TypeString,
 			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_REALM", "master"),
 		},
+		// Keycloak 17+ serves from '/' by default while older versions use '/auth'. If this is not set,
+		// the base path is detected when the provider is configured. An empty base path can't be told apart
+		// from an unset one, so detection is requested with a sentinel value instead.
+		"base_path": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_BASE_PATH", detectBasePath),
+		},
+
+		// HTTP transport settings. Certificates and keys are expected in PEM format.
+		"root_ca_certificate": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			Description: "PEM encoded CA certificate(s) trusted in addition to the system roots",
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_ROOT_CA_CERTIFICATE", nil),
+		},
+		"tls_client_certificate": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_CERTIFICATE", nil),
+		},
+		"tls_client_key": {
+			Optional:    true,
+			Sensitive:   true,
+			Type:        schema.TypeString,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_KEY", nil),
+		},
+		"tls_insecure_skip_verify": {
+			Optional:    true,
+			Type:        schema.TypeBool,
+			DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_TLS_INSECURE_SKIP_VERIFY", false),
+		},
+		"client_timeout": {
+			Optional:     true,
+			Type:         schema.TypeInt,
+			Description:  "Timeout in seconds for requests to Keycloak, 0 disables the timeout",
+			DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_CLIENT_TIMEOUT", 30),
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"retry_max_attempts": {
+			Optional:     true,
+			Type:         schema.TypeInt,
+			Description:  "Maximum number of attempts for requests failing with transient errors, 1 disables retries",
+			DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_MAX_ATTEMPTS", 3),
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"retry_min_backoff": {
+			Optional:     true,
+			Type:         schema.TypeInt,
+			Description:  "Wait time in seconds before the first retry, doubled on every further attempt",
+			DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_MIN_BACKOFF", 1),
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"retry_max_backoff": {
+			Optional:     true,
+			Type:         schema.TypeInt,
+			Description:  "Maximum wait time in seconds between retries, including waits requested with Retry-After",
+			DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_MAX_BACKOFF", 30),
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"retry_jitter": {
+			Optional: true,
+			Type:     schema.TypeBool,
+			Default:  true,
+		},
+		"http_proxy": {
+			Optional:    true,
+			Type:        schema.TypeString,
+			Description: "Proxy URL for requests to Keycloak, defaults to the HTTP(S)_PROXY environment variables",
+		},
 	}
 }
 
-// This method attempts to log in to Keycloak with the provided client credentials
-// and returns a configured Keycloak client.
+// The value of base_path for detecting the base path of the Keycloak server.
+const detectBasePath = "auto"
+
+// The public client that Keycloak creates in every realm for administrative logins.
+const adminCliClientId = "admin-cli"
+
+// The ways in which the provider can authenticate against the Keycloak API.
+const (
+	loginModeClientCredentials = "client credentials"
+	loginModePassword          = "password"
+	loginModeAccessToken       = "access token"
+	loginModeAccessTokenFile   = "access token file"
+)
+
+// This method attempts to log in to Keycloak with the provided credentials (or uses the provided
+// access token) and returns a configured Keycloak client.
 func keycloakProviderSetup(data *schema.ResourceData) (interface{}, error) {
-	return keycloak.Login(
-		data.Get("client_id").(string),
-		data.Get("client_secret").(string),
-		data.Get("api_base").(string),
-		data.Get("realm").(string),
-	)
+	clientId := data.Get("client_id").(string)
+	clientSecret := data.Get("client_secret").(string)
+	username := data.Get("username").(string)
+	password := data.Get("password").(string)
+	apiBase := data.Get("api_base").(string)
+	realm := data.Get("realm").(string)
+
+	mode, err := loginMode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := keycloak.NewHttpClient(&keycloak.TransportConfig{
+		RootCaCertificate:     data.Get("root_ca_certificate").(string),
+		TlsClientCertificate:  data.Get("tls_client_certificate").(string),
+		TlsClientKey:          data.Get("tls_client_key").(string),
+		TlsInsecureSkipVerify: data.Get("tls_insecure_skip_verify").(bool),
+		Timeout:               time.Duration(data.Get("client_timeout").(int)) * time.Second,
+		ProxyUrl:              data.Get("http_proxy").(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := []keycloak.ClientOption{
+		keycloak.WithHttpClient(httpClient),
+		keycloak.WithRetryPolicy(keycloak.RetryPolicy{
+			MaxAttempts: data.Get("retry_max_attempts").(int),
+			MinBackoff:  time.Duration(data.Get("retry_min_backoff").(int)) * time.Second,
+			MaxBackoff:  time.Duration(data.Get("retry_max_backoff").(int)) * time.Second,
+			Jitter:      data.Get("retry_jitter").(bool),
+		}),
+	}
+
+	if basePath := data.Get("base_path").(string); basePath != detectBasePath {
+		options = append(options, keycloak.WithBasePath(basePath))
+	}
+
+	switch mode {
+	case loginModeAccessToken:
+		return keycloak.NewClientWithToken(data.Get("access_token").(string), apiBase, options...)
+	case loginModeAccessTokenFile:
+		return keycloak.NewClientWithTokenFile(data.Get("access_token_file").(string), apiBase, options...)
+	case loginModePassword:
+		if clientId == "" {
+			clientId = adminCliClientId
+		}
+
+		return keycloak.LoginWithPassword(clientId, clientSecret, username, password, apiBase, realm, options...)
+	default:
+		return keycloak.Login(clientId, clientSecret, apiBase, realm, options...)
+	}
+}
+
+// Determines which login mode is configured and checks that settings of different modes are not mixed.
+func loginMode(data *schema.ResourceData) (string, error) {
+	clientId := data.Get("client_id").(string)
+	clientSecret := data.Get("client_secret").(string)
+	username := data.Get("username").(string)
+	password := data.Get("password").(string)
+	accessToken := data.Get("access_token").(string)
+	accessTokenFile := data.Get("access_token_file").(string)
+
+	if accessToken != "" || accessTokenFile != "" {
+		if accessToken != "" && accessTokenFile != "" {
+			return "", fmt.Errorf("Only one of access_token and access_token_file may be set")
+		}
+
+		if clientSecret != "" || username != "" || password != "" {
+			return "", fmt.Errorf("access_token and access_token_file can not be combined with client_secret, username or password")
+		}
+
+		if accessToken != "" {
+			return loginModeAccessToken, nil
+		}
+		return loginModeAccessTokenFile, nil
+	}
+
+	if username != "" || password != "" {
+		if username == "" || password == "" {
+			return "", fmt.Errorf("Both username and password must be set to use the password grant")
+		}
+
+		return loginModePassword, nil
+	}
+
+	if clientId == "" || clientSecret == "" {
+		return "", fmt.Errorf("Either client_id and client_secret (client credentials grant), username and password (password grant) or an access token must be set")
+	}
+
+	return loginModeClientCredentials, nil
 }
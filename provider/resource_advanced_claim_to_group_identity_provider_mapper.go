@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Keycloak stores the claims that are matched as a JSON list in the mapper's config.
+type identityProviderMapperClaim struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Adds users to a group if their ID token contains all of the given claims.
+func resourceAdvancedClaimToGroupIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: fixedIdentityProviderMapper("oidc-advanced-group-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			// Claim names mapped to the values they must have
+			"claims": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"claim_values_are_regex": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// The path of the group, i.e. the 'path' attribute of keycloak_group
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			claims := []identityProviderMapperClaim{}
+			for key, value := range getOptionalStringMap(d, "claims") {
+				claims = append(claims, identityProviderMapperClaim{Key: key, Value: value})
+			}
+			sort.Slice(claims, func(i, j int) bool { return claims[i].Key < claims[j].Key })
+
+			rawClaims, _ := json.Marshal(claims)
+			config["claims"] = string(rawClaims)
+			config["are.claim.values.regex"] = strconv.FormatBool(d.Get("claim_values_are_regex").(bool))
+			config["group"] = d.Get("group").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			var claims []identityProviderMapperClaim
+			json.Unmarshal([]byte(config["claims"]), &claims)
+
+			claimMap := map[string]string{}
+			for _, claim := range claims {
+				claimMap[claim.Key] = claim.Value
+			}
+
+			d.Set("claims", claimMap)
+			d.Set("claim_values_are_regex", parseConfigBool(config["are.claim.values.regex"]))
+			d.Set("group", config["group"])
+		},
+	})
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Imports a claim (OpenID Connect) or an assertion attribute (SAML) into a user attribute.
+func resourceAttributeImporterIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: protocolIdentityProviderMapper("oidc-user-attribute-idp-mapper", "saml-user-attribute-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			"user_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// For OpenID Connect identity providers. Nested claims are referenced as 'address.country'.
+			"claim_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// For SAML identity providers, either the name or the friendly name of the attribute.
+			"attribute_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"attribute_friendly_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["user.attribute"] = d.Get("user_attribute").(string)
+			config["claim"] = d.Get("claim_name").(string)
+			config["attribute.name"] = d.Get("attribute_name").(string)
+			config["attribute.friendly.name"] = d.Get("attribute_friendly_name").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("user_attribute", config["user.attribute"])
+			d.Set("claim_name", config["claim"])
+			d.Set("attribute_name", config["attribute.name"])
+			d.Set("attribute_friendly_name", config["attribute.friendly.name"])
+		},
+	})
+}
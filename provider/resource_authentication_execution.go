@@ -0,0 +1,151 @@
+// This file provides a Terraform resource for executions of Keycloak authentication flows
+// The execution resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_authenticationexecutionrepresentation
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationExecution() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationExecutionRead),
+		Create: schema.CreateFunc(resourceAuthenticationExecutionCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationExecutionUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationExecutionDelete),
+
+		// Executions are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationExecutionHelper,
+		},
+
+		Schema: mergeSchemas(authenticationExecutionSchema(), map[string]*schema.Schema{
+			// The authenticator that is run, e.g. 'auth-otp-form' or 'identity-provider-redirector'
+			"authenticator": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+// Executions and subflows are both placed in a parent flow with a requirement and a priority.
+func authenticationExecutionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"realm": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		// The 'id' attribute of keycloak_authentication_flow or keycloak_authentication_subflow
+		"parent_flow_id": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"requirement": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "DISABLED",
+			ValidateFunc: validation.StringInSlice([]string{"REQUIRED", "ALTERNATIVE", "CONDITIONAL", "DISABLED"}, false),
+		},
+		// Executions run in the order of their priority, lowest first. Keycloak can not change the
+		// priority of existing executions, so they are re-created.
+		"priority": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			ForceNew: true,
+			Default:  0,
+		},
+	}
+}
+
+func importAuthenticationExecutionHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationExecutionRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	execution, err := apiClient.GetAuthenticationExecution(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("authenticator", execution.Authenticator)
+	authenticationExecutionToResourceData(execution, d)
+
+	return nil
+}
+
+func resourceAuthenticationExecutionCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	execution := resourceDataToAuthenticationExecution(d)
+	execution.Authenticator = d.Get("authenticator").(string)
+
+	created, err := apiClient.CreateAuthenticationExecution(realm(d), execution)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAuthenticationExecutionRead(d, m)
+}
+
+func resourceAuthenticationExecutionUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := updateAuthenticationExecutionRequirement(apiClient, d, d.Id())
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationExecutionRead(d, m)
+}
+
+func resourceAuthenticationExecutionDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteAuthenticationExecution(realm(d), d.Id())
+}
+
+func resourceDataToAuthenticationExecution(d *schema.ResourceData) *keycloak.AuthenticationExecution {
+	return &keycloak.AuthenticationExecution{
+		ParentFlow:  d.Get("parent_flow_id").(string),
+		Requirement: d.Get("requirement").(string),
+		Priority:    d.Get("priority").(int),
+	}
+}
+
+func authenticationExecutionToResourceData(execution *keycloak.AuthenticationExecution, d *schema.ResourceData) {
+	d.Set("parent_flow_id", execution.ParentFlow)
+	d.Set("requirement", execution.Requirement)
+	d.Set("priority", execution.Priority)
+}
+
+// The requirement is changed through the parent flow, which is referenced by its alias.
+func updateAuthenticationExecutionRequirement(apiClient *keycloak.KeycloakClient, d *schema.ResourceData, executionId string) error {
+	if !d.HasChange("requirement") {
+		return nil
+	}
+
+	parentFlow, err := apiClient.GetAuthenticationFlow(realm(d), d.Get("parent_flow_id").(string))
+	if err != nil {
+		return err
+	}
+
+	return apiClient.UpdateAuthenticationExecutionRequirement(realm(d), parentFlow.Alias, executionId, d.Get("requirement").(string))
+}
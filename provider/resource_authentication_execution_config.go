@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Configures the authenticator of an execution, e.g. the identity provider that 'identity-provider-redirector'
+// redirects to. The available config keys depend on the authenticator.
+func resourceAuthenticationExecutionConfig() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationExecutionConfigRead),
+		Create: schema.CreateFunc(resourceAuthenticationExecutionConfigCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationExecutionConfigUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationExecutionConfigDelete),
+
+		// Execution configs are importable as '${realm}.${execution_id}.${config_id}'.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationExecutionConfigHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The 'id' attribute of keycloak_authentication_execution
+			"execution_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func importAuthenticationExecutionConfigHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 2)
+	if !ok {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.${execution_id}.${config_id}'")
+	}
+
+	d.Set("realm", realm)
+	d.Set("execution_id", ids[0])
+	d.SetId(ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationExecutionConfigRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	config, err := apiClient.GetAuthenticationExecutionConfig(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("alias", config.Alias)
+	d.Set("config", config.Config)
+
+	return nil
+}
+
+func resourceAuthenticationExecutionConfigCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateAuthenticationExecutionConfig(realm(d), d.Get("execution_id").(string), resourceDataToAuthenticationExecutionConfig(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAuthenticationExecutionConfigRead(d, m)
+}
+
+func resourceAuthenticationExecutionConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateAuthenticationExecutionConfig(realm(d), resourceDataToAuthenticationExecutionConfig(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationExecutionConfigRead(d, m)
+}
+
+func resourceAuthenticationExecutionConfigDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteAuthenticationExecutionConfig(realm(d), d.Id())
+}
+
+func resourceDataToAuthenticationExecutionConfig(d *schema.ResourceData) *keycloak.AuthenticationExecutionConfig {
+	c := keycloak.AuthenticationExecutionConfig{
+		Alias:  d.Get("alias").(string),
+		Config: getOptionalStringMap(d, "config"),
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return &c
+}
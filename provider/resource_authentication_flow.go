@@ -0,0 +1,122 @@
+// This file provides a Terraform resource for top-level Keycloak authentication flows
+// The flow resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_authenticationflowrepresentation
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationFlow() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationFlowRead),
+		Create: schema.CreateFunc(resourceAuthenticationFlowCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationFlowUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationFlowDelete),
+
+		// Authentication flows are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationFlowHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Flows are bound to the realm and clients by their alias.
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// 'basic-flow' authenticates users, 'client-flow' authenticates clients.
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "basic-flow",
+				ValidateFunc: validation.StringInSlice([]string{"basic-flow", "client-flow"}, false),
+			},
+		},
+	}
+}
+
+func importAuthenticationFlowHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationFlowRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	flow, err := apiClient.GetAuthenticationFlow(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("alias", flow.Alias)
+	d.Set("description", flow.Description)
+	d.Set("provider_id", flow.ProviderId)
+
+	return nil
+}
+
+func resourceAuthenticationFlowCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateAuthenticationFlow(realm(d), resourceDataToAuthenticationFlow(d, true))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceAuthenticationFlowRead(d, m)
+}
+
+func resourceAuthenticationFlowUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateAuthenticationFlow(realm(d), resourceDataToAuthenticationFlow(d, true))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationFlowRead(d, m)
+}
+
+func resourceAuthenticationFlowDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteAuthenticationFlow(realm(d), d.Id())
+}
+
+// Used for both top-level flows and subflows.
+func resourceDataToAuthenticationFlow(d *schema.ResourceData, topLevel bool) *keycloak.AuthenticationFlow {
+	f := keycloak.AuthenticationFlow{
+		Alias:       d.Get("alias").(string),
+		Description: d.Get("description").(string),
+		ProviderId:  d.Get("provider_id").(string),
+		TopLevel:    topLevel,
+	}
+
+	if !d.IsNewResource() {
+		f.Id = d.Id()
+	}
+
+	return &f
+}
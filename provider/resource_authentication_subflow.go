@@ -0,0 +1,141 @@
+// This file provides a Terraform resource for subflows of Keycloak authentication flows. A subflow is a
+// flow that is run by an execution of its parent flow, this resource manages both.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceAuthenticationSubflow() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceAuthenticationSubflowRead),
+		Create: schema.CreateFunc(resourceAuthenticationSubflowCreate),
+		Update: schema.UpdateFunc(resourceAuthenticationSubflowUpdate),
+		Delete: schema.DeleteFunc(resourceAuthenticationSubflowDelete),
+
+		// Subflows are importable as '${realm}.${flow_id}.${execution_id}'.
+		Importer: &schema.ResourceImporter{
+			State: importAuthenticationSubflowHelper,
+		},
+
+		Schema: mergeSchemas(authenticationExecutionSchema(), map[string]*schema.Schema{
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// 'form-flow' combines the form authenticators of its executions into a single form.
+			"provider_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "basic-flow",
+				ValidateFunc: validation.StringInSlice([]string{"basic-flow", "form-flow"}, false),
+			},
+			// The form that renders a form flow, e.g. 'registration-page-form'
+			"authenticator": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// The execution of the parent flow that runs the subflow
+			"execution_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}),
+	}
+}
+
+func importAuthenticationSubflowHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 2)
+	if !ok {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.${flow_id}.${execution_id}'")
+	}
+
+	d.Set("realm", realm)
+	d.SetId(ids[0])
+	d.Set("execution_id", ids[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAuthenticationSubflowRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	flow, err := apiClient.GetAuthenticationFlow(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	execution, err := apiClient.GetAuthenticationExecution(realm(d), d.Get("execution_id").(string))
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("alias", flow.Alias)
+	d.Set("description", flow.Description)
+	d.Set("provider_id", flow.ProviderId)
+	d.Set("authenticator", execution.Authenticator)
+	authenticationExecutionToResourceData(execution, d)
+
+	return nil
+}
+
+func resourceAuthenticationSubflowCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	flow, err := apiClient.CreateAuthenticationFlow(realm(d), resourceDataToAuthenticationFlow(d, false))
+	if err != nil {
+		return err
+	}
+
+	execution := resourceDataToAuthenticationExecution(d)
+	execution.AuthenticatorFlow = true
+	execution.FlowId = flow.Id
+	execution.Authenticator = d.Get("authenticator").(string)
+
+	created, err := apiClient.CreateAuthenticationExecution(realm(d), execution)
+	if err != nil {
+		// The flow is not part of any other flow yet, so it would be left behind.
+		apiClient.DeleteAuthenticationFlow(realm(d), flow.Id)
+		return err
+	}
+
+	d.SetId(flow.Id)
+	d.Set("execution_id", created.Id)
+
+	return resourceAuthenticationSubflowRead(d, m)
+}
+
+func resourceAuthenticationSubflowUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateAuthenticationFlow(realm(d), resourceDataToAuthenticationFlow(d, false))
+	if err != nil {
+		return err
+	}
+
+	err = updateAuthenticationExecutionRequirement(apiClient, d, d.Get("execution_id").(string))
+	if err != nil {
+		return err
+	}
+
+	return resourceAuthenticationSubflowRead(d, m)
+}
+
+// Deleting the execution also deletes the subflow.
+func resourceAuthenticationSubflowDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteAuthenticationExecution(realm(d), d.Get("execution_id").(string))
+}
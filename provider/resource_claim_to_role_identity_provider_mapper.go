@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Assigns a role to users whose ID token contains a claim with a specific value.
+func resourceClaimToRoleIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: fixedIdentityProviderMapper("oidc-role-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			"claim_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// The name of a realm role, or '${client_id}.${role_name}' for client roles
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["claim"] = d.Get("claim_name").(string)
+			config["claim.value"] = d.Get("claim_value").(string)
+			config["role"] = d.Get("role").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("claim_name", config["claim"])
+			d.Set("claim_value", config["claim.value"])
+			d.Set("role", config["role"])
+		},
+	})
+}
@@ -4,10 +4,30 @@
 package provider
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/tazjin/terraform-provider-keycloak/keycloak"
 )
 
+// OpenID Connect settings that are stored as client attributes rather than as fields of the client.
+const (
+	postLogoutRedirectUrisAttribute  = "post.logout.redirect.uris"
+	pkceCodeChallengeMethodAttribute = "pkce.code.challenge.method"
+	backchannelLogoutUrlAttribute    = "backchannel.logout.url"
+	accessTokenLifespanAttribute     = "access.token.lifespan"
+)
+
+var clientTypedAttributes = []string{
+	postLogoutRedirectUrisAttribute,
+	pkceCodeChallengeMethodAttribute,
+	backchannelLogoutUrlAttribute,
+	accessTokenLifespanAttribute,
+}
+
 func resourceClient() *schema.Resource {
 	return &schema.Resource{
 		// API methods
@@ -31,11 +51,27 @@ func resourceClient() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			// Display name of the client, e.g. on the consent screen
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  true,
 			},
+			// Keycloak generates a secret for confidential clients if this is not set.
+			"client_secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+			},
 			"client_authenticator_type": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -71,12 +107,113 @@ func resourceClient() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
-
-			// Computed fields (i.e. things looked up in Keycloak after client creation)
-			"client_secret": {
+			"standard_flow_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"implicit_flow_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"direct_access_grants_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"consent_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Whether tokens contain all roles of the user instead of only the roles in the client's scope
+			"full_scope_allowed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"root_url": {
 				Type:     schema.TypeString,
-				Computed: true,
+				Optional: true,
+			},
+			"base_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"admin_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"valid_post_logout_redirect_uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			// PKCE is not enforced if this is empty
+			"pkce_code_challenge_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"", "plain", "S256"}, false),
+			},
+			"front_channel_logout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"backchannel_logout_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Lifespan of access tokens in seconds, the realm's setting is used if this is not set.
+			"access_token_lifespan": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			// Additional client attributes. Only the attributes listed here are managed, other attributes
+			// (e.g. defaults set by Keycloak) are left untouched.
+			"attributes": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateClientAttributes,
+			},
+			// Authentication flows that are used for this client instead of the realm's flows
+			"authentication_flow_binding_overrides": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						// The 'id' attribute of keycloak_authentication_flow
+						"browser_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"direct_grant_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			// Names of the assigned client scopes. Scopes that the realm assigns to new clients are removed
+			// unless they are listed here.
+			"default_client_scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"optional_client_scopes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			// Computed fields (i.e. things looked up in Keycloak after client creation)
 			"service_account_user_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -104,7 +241,7 @@ func resourceClientRead(d *schema.ResourceData, m interface{}) error {
 
 	client, err := c.GetClient(d.Id(), realm(d))
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	clientToResourceData(client, d)
@@ -126,6 +263,21 @@ func resourceClientRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("service_account_user_id", user.Id)
 	}
 
+	// Bearer-only clients do not issue tokens and have no client scopes
+	if !client.BearerOnly {
+		defaultScopes, err := c.GetClientClientScopes(realm(d), d.Id(), keycloak.DefaultClientScopes)
+		if err != nil {
+			return err
+		}
+		d.Set("default_client_scopes", clientScopeNames(defaultScopes))
+
+		optionalScopes, err := c.GetClientClientScopes(realm(d), d.Id(), keycloak.OptionalClientScopes)
+		if err != nil {
+			return err
+		}
+		d.Set("optional_client_scopes", clientScopeNames(optionalScopes))
+	}
+
 	return nil
 }
 
@@ -140,13 +292,82 @@ func resourceClientCreate(d *schema.ResourceData, m interface{}) error {
 
 	d.SetId(created.Id)
 
+	err = updateClientClientScopes(d, apiClient)
+	if err != nil {
+		return err
+	}
+
 	return resourceClientRead(d, m)
 }
 
 func resourceClientUpdate(d *schema.ResourceData, m interface{}) error {
 	client := resourceDataToClient(d)
 	apiClient := m.(*keycloak.KeycloakClient)
-	return apiClient.UpdateClient(&client, realm(d))
+
+	err := apiClient.UpdateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return updateClientClientScopes(d, apiClient)
+}
+
+// The assigned client scopes are reconciled with the configured ones whenever they change and on creation, which
+// removes the scopes that the realm assigned to the new client unless they are configured.
+func updateClientClientScopes(d *schema.ResourceData, apiClient *keycloak.KeycloakClient) error {
+	type clientScopeChange struct {
+		assignment keycloak.ClientScopeAssignment
+		current    []keycloak.ClientScope
+		desired    []string
+	}
+
+	// Bearer-only clients do not issue tokens and have no client scopes
+	if d.Get("bearer_only").(bool) {
+		return nil
+	}
+
+	changes := []clientScopeChange{}
+	for _, key := range []string{"default_client_scopes", "optional_client_scopes"} {
+		if !d.IsNewResource() && !d.HasChange(key) {
+			continue
+		}
+
+		assignment := keycloak.DefaultClientScopes
+		if key == "optional_client_scopes" {
+			assignment = keycloak.OptionalClientScopes
+		}
+
+		current, err := apiClient.GetClientClientScopes(realm(d), d.Id(), assignment)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, clientScopeChange{assignment, current, getOptionalStringSet(d, key)})
+	}
+
+	// A scope can only have one assignment type, so all scopes are removed before any are added. This allows
+	// moving a scope from the default to the optional scopes and vice versa.
+	for _, change := range changes {
+		assignment := change.assignment
+		err := removeClientScopeAssignments(change.current, change.desired, func(scopeId string) error {
+			return apiClient.RemoveClientClientScope(realm(d), d.Id(), assignment, scopeId)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		assignment := change.assignment
+		err := addClientScopeAssignments(apiClient, realm(d), change.current, change.desired, func(scopeId string) error {
+			return apiClient.AddClientClientScope(realm(d), d.Id(), assignment, scopeId)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func resourceClientDelete(d *schema.ResourceData, m interface{}) error {
@@ -170,26 +391,76 @@ func resourceDataToClient(d *schema.ResourceData) keycloak.Client {
 	}
 
 	c := keycloak.Client{
-		ClientId:                d.Get("client_id").(string),
-		Enabled:                 d.Get("enabled").(bool),
-		ClientAuthenticatorType: d.Get("client_authenticator_type").(string),
-		RedirectUris:            redirectUris,
-		Protocol:                d.Get("protocol").(string),
-		PublicClient:            d.Get("public_client").(bool),
-		BearerOnly:              d.Get("bearer_only").(bool),
-		ServiceAccountsEnabled:  d.Get("service_accounts_enabled").(bool),
-		WebOrigins:              webOrigins,
+		ClientId:                  d.Get("client_id").(string),
+		Name:                      d.Get("name").(string),
+		Description:               d.Get("description").(string),
+		Enabled:                   d.Get("enabled").(bool),
+		ClientAuthenticatorType:   d.Get("client_authenticator_type").(string),
+		RedirectUris:              redirectUris,
+		Protocol:                  d.Get("protocol").(string),
+		PublicClient:              d.Get("public_client").(bool),
+		BearerOnly:                d.Get("bearer_only").(bool),
+		ServiceAccountsEnabled:    d.Get("service_accounts_enabled").(bool),
+		WebOrigins:                webOrigins,
+		Secret:                    d.Get("client_secret").(string),
+		RootUrl:                   d.Get("root_url").(string),
+		BaseUrl:                   d.Get("base_url").(string),
+		AdminUrl:                  d.Get("admin_url").(string),
+		StandardFlowEnabled:       getBoolPointer(d, "standard_flow_enabled"),
+		ImplicitFlowEnabled:       getBoolPointer(d, "implicit_flow_enabled"),
+		DirectAccessGrantsEnabled: getBoolPointer(d, "direct_access_grants_enabled"),
+		ConsentRequired:           getBoolPointer(d, "consent_required"),
+		FullScopeAllowed:          getBoolPointer(d, "full_scope_allowed"),
+		FrontchannelLogout:        getBoolPointer(d, "front_channel_logout"),
+		Attributes:                resourceDataToClientAttributes(d),
+
+		AuthenticationFlowBindingOverrides: map[string]string{
+			"browser":      d.Get("authentication_flow_binding_overrides.0.browser_id").(string),
+			"direct_grant": d.Get("authentication_flow_binding_overrides.0.direct_grant_id").(string),
+		},
 	}
 
 	if !d.IsNewResource() {
 		c.Id = d.Id()
+
+		// The secret in the state may be outdated if it was rotated, so it is only sent if it was changed.
+		if !d.HasChange("client_secret") {
+			c.Secret = ""
+		}
 	}
 
 	return c
 }
 
+func resourceDataToClientAttributes(d *schema.ResourceData) map[string]string {
+	attributes := map[string]string{}
+
+	// Keycloak merges attributes on update, so removed attributes are explicitly cleared.
+	oldAttributes, _ := d.GetChange("attributes")
+	for key := range oldAttributes.(map[string]interface{}) {
+		attributes[key] = ""
+	}
+
+	for key, value := range getOptionalStringMap(d, "attributes") {
+		attributes[key] = value
+	}
+
+	attributes[postLogoutRedirectUrisAttribute] = strings.Join(getOptionalStringList(d, "valid_post_logout_redirect_uris"), "##")
+	attributes[pkceCodeChallengeMethodAttribute] = d.Get("pkce_code_challenge_method").(string)
+	attributes[backchannelLogoutUrlAttribute] = d.Get("backchannel_logout_url").(string)
+
+	attributes[accessTokenLifespanAttribute] = ""
+	if lifespan := d.Get("access_token_lifespan").(int); lifespan > 0 {
+		attributes[accessTokenLifespanAttribute] = strconv.Itoa(lifespan)
+	}
+
+	return attributes
+}
+
 func clientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
 	d.Set("client_id", c.ClientId)
+	d.Set("name", c.Name)
+	d.Set("description", c.Description)
 	d.Set("enabled", c.Enabled)
 	d.Set("client_authenticator_type", c.ClientAuthenticatorType)
 	d.Set("redirect_uris", c.RedirectUris)
@@ -198,4 +469,54 @@ func clientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
 	d.Set("bearer_only", c.BearerOnly)
 	d.Set("service_accounts_enabled", c.ServiceAccountsEnabled)
 	d.Set("web_origins", c.WebOrigins)
+	d.Set("root_url", c.RootUrl)
+	d.Set("base_url", c.BaseUrl)
+	d.Set("admin_url", c.AdminUrl)
+	setOptionalBool(d, "standard_flow_enabled", c.StandardFlowEnabled)
+	setOptionalBool(d, "implicit_flow_enabled", c.ImplicitFlowEnabled)
+	setOptionalBool(d, "direct_access_grants_enabled", c.DirectAccessGrantsEnabled)
+	setOptionalBool(d, "consent_required", c.ConsentRequired)
+	setOptionalBool(d, "full_scope_allowed", c.FullScopeAllowed)
+	setOptionalBool(d, "front_channel_logout", c.FrontchannelLogout)
+
+	overrides := []interface{}{}
+	if c.AuthenticationFlowBindingOverrides["browser"] != "" || c.AuthenticationFlowBindingOverrides["direct_grant"] != "" {
+		overrides = append(overrides, map[string]interface{}{
+			"browser_id":      c.AuthenticationFlowBindingOverrides["browser"],
+			"direct_grant_id": c.AuthenticationFlowBindingOverrides["direct_grant"],
+		})
+	}
+	d.Set("authentication_flow_binding_overrides", overrides)
+
+	postLogoutRedirectUris := []string{}
+	if uris := c.Attributes[postLogoutRedirectUrisAttribute]; uris != "" {
+		postLogoutRedirectUris = strings.Split(uris, "##")
+	}
+	d.Set("valid_post_logout_redirect_uris", postLogoutRedirectUris)
+	d.Set("pkce_code_challenge_method", c.Attributes[pkceCodeChallengeMethodAttribute])
+	d.Set("backchannel_logout_url", c.Attributes[backchannelLogoutUrlAttribute])
+
+	lifespan, _ := strconv.Atoi(c.Attributes[accessTokenLifespanAttribute])
+	d.Set("access_token_lifespan", lifespan)
+
+	// Only attributes that are already managed are read, so that changes to them are detected without
+	// adding every attribute that Keycloak sets by default. Cleared attributes are stored as empty strings.
+	attributes := map[string]string{}
+	for key := range getOptionalStringMap(d, "attributes") {
+		if value := c.Attributes[key]; value != "" {
+			attributes[key] = value
+		}
+	}
+	d.Set("attributes", attributes)
+}
+
+// Attributes that are exposed as their own resource attributes can not be set through 'attributes'.
+func validateClientAttributes(v interface{}, k string) ([]string, []error) {
+	var errors []error
+	for key := range v.(map[string]interface{}) {
+		if contains(clientTypedAttributes, key) {
+			errors = append(errors, fmt.Errorf("%s: client attribute '%s' is managed by its own resource attribute", k, key))
+		}
+	}
+	return nil, errors
 }
@@ -94,7 +94,7 @@ func resourceClientRoleRead(d *schema.ResourceData, m interface{}) error {
 	d.Partial(true)
 	readRole, err := apiClient.GetClientRole(clientId(d), realm(d), d.Get("name").(string))
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	d.Set("name", readRole.Name)
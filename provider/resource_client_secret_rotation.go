@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Regenerates the secret of a confidential client whenever the resource is re-created, e.g. because one of
+// the keepers changed. The client's own client_secret attribute should not be set when this is used.
+func resourceClientSecretRotation() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceClientSecretRotationRead),
+		Create: schema.CreateFunc(resourceClientSecretRotationCreate),
+		Delete: schema.DeleteFunc(resourceClientSecretRotationDelete),
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The internal ID of the client, i.e. the 'id' attribute of keycloak_client
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Arbitrary values that trigger a rotation when they change, e.g. a timestamp.
+			"keepers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"client_secret": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceClientSecretRotationRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	// The secret is read back so that secrets changed outside of this resource are reflected.
+	secret, err := apiClient.GetClientSecret(clientId(d), realm(d))
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("client_secret", secret.Value)
+	return nil
+}
+
+func resourceClientSecretRotationCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	secret, err := apiClient.RegenerateClientSecret(clientId(d), realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(clientId(d))
+	d.Set("client_secret", secret.Value)
+
+	return nil
+}
+
+// Deleting the rotation keeps the client's current secret.
+func resourceClientSecretRotationDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
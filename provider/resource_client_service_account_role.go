@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Manages all roles that are assigned to the service account of a client, so only one of these
+// resources should exist per client. The realm's default roles are kept unless they are configured.
+func resourceClientServiceAccountRole() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceClientServiceAccountRoleRead),
+		Create: schema.CreateFunc(resourceClientServiceAccountRoleCreate),
+		Update: schema.UpdateFunc(resourceClientServiceAccountRoleUpdate),
+		Delete: schema.DeleteFunc(resourceClientServiceAccountRoleDelete),
+
+		// Service account roles are importable by client ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importClientServiceAccountRoleHelper,
+		},
+
+		Schema: mergeSchemas(roleMappingSchema(), map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The internal ID of the client, i.e. the 'id' attribute of keycloak_client. The client
+			// must have service_accounts_enabled set.
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func importClientServiceAccountRoleHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, clientId, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(clientId)
+	d.Set("realm", realm)
+	d.Set("client_id", clientId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// The service account user is looked up on every change, as it is replaced when service
+// accounts are disabled and enabled again.
+func serviceAccountRoleMappingTarget(apiClient *keycloak.KeycloakClient, d *schema.ResourceData) (roleMappingTarget, error) {
+	user, err := apiClient.GetClientServiceAccountUser(d.Id(), realm(d))
+	if err != nil {
+		return roleMappingTarget{}, err
+	}
+
+	return userRoleMappingTarget(apiClient, realm(d), user.Id), nil
+}
+
+func resourceClientServiceAccountRoleRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	target, err := serviceAccountRoleMappingTarget(apiClient, d)
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	r, err := apiClient.GetRealm(realm(d))
+	if err != nil {
+		return err
+	}
+
+	clients, err := apiClient.GetClients(realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.Set("client_id", d.Id())
+	return readRoleMappings(target, d, unmanagedRoles{
+		realmRoles:  realmDefaultRoles(r),
+		clientRoles: clientDefaultRoles(clients),
+	})
+}
+
+// Keycloak assigns the realm's default roles to new service accounts. Older versions assign the default roles
+// directly, newer ones a composite role named after the realm. These are not managed unless they are configured.
+func realmDefaultRoles(r *keycloak.Realm) []string {
+	return append([]string{"default-roles-" + strings.ToLower(r.Realm)}, r.DefaultRoles...)
+}
+
+// Versions before 13 also assign the default roles of every client directly, e.g. account's manage-account and
+// view-profile. Newer versions include them in the realm's composite role and no longer return them for clients.
+func clientDefaultRoles(clients []keycloak.Client) map[string][]string {
+	defaultRoles := map[string][]string{}
+	for _, client := range clients {
+		if len(client.DefaultRoles) > 0 {
+			defaultRoles[client.Id] = client.DefaultRoles
+		}
+	}
+	return defaultRoles
+}
+
+func resourceClientServiceAccountRoleCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(clientId(d))
+
+	return resourceClientServiceAccountRoleUpdate(d, m)
+}
+
+func resourceClientServiceAccountRoleUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	target, err := serviceAccountRoleMappingTarget(apiClient, d)
+	if err != nil {
+		return err
+	}
+
+	err = updateRoleMappings(apiClient, target, d)
+	if err != nil {
+		return err
+	}
+
+	return resourceClientServiceAccountRoleRead(d, m)
+}
+
+func resourceClientServiceAccountRoleDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	// The roles are gone with the service account if the client was deleted first.
+	target, err := serviceAccountRoleMappingTarget(apiClient, d)
+	if keycloak.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return deleteRoleMappings(apiClient, target, d)
+}
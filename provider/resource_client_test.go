@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Serves the realm's client scopes and records all requests that change the assignments of a client.
+func newClientScopesServer(changes *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/admin/realms/test/clients/app-id/default-client-scopes":
+			fmt.Fprint(w, `[{"id":"profile-id","name":"profile"}]`)
+		case "/admin/realms/test/clients/app-id/optional-client-scopes":
+			fmt.Fprint(w, `[{"id":"email-id","name":"email"}]`)
+		case "/admin/realms/test/client-scopes":
+			fmt.Fprint(w, `[{"id":"profile-id","name":"profile"},{"id":"email-id","name":"email"}]`)
+		default:
+			*changes = append(*changes, r.Method+" "+r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestNewClientIsReconciledWithConfiguredScopes(t *testing.T) {
+	changes := []string{}
+	server := newClientScopesServer(&changes)
+	defer server.Close()
+
+	apiClient, _ := keycloak.NewClientWithToken("token", server.URL, keycloak.WithBasePath(""))
+
+	// The email scope assigned by the realm is moved to the default scopes, and the optional scopes are
+	// explicitly empty.
+	d := schema.TestResourceDataRaw(t, resourceClient().Schema, map[string]interface{}{
+		"realm":                  "test",
+		"client_id":              "app",
+		"redirect_uris":          []interface{}{},
+		"default_client_scopes":  []interface{}{"profile", "email"},
+		"optional_client_scopes": []interface{}{},
+	})
+	d.SetId("app-id")
+	d.MarkNewResource()
+
+	if err := updateClientClientScopes(d, apiClient); err != nil {
+		t.Fatalf("Client scopes were not updated: %s", err)
+	}
+
+	expected := []string{
+		"DELETE /admin/realms/test/clients/app-id/optional-client-scopes/email-id",
+		"PUT /admin/realms/test/clients/app-id/default-client-scopes/email-id",
+	}
+	if fmt.Sprint(changes) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v, got %v", expected, changes)
+	}
+}
@@ -4,6 +4,8 @@
 package provider
 
 import (
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/tazjin/terraform-provider-keycloak/keycloak"
 )
@@ -35,31 +37,55 @@ func resourceGroup() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			// Keycloak ignores role assignments in group representations on create and update.
 			"realmroles": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:       schema.TypeList,
+				Optional:   true,
+				Elem:       &schema.Schema{Type: schema.TypeString},
+				Deprecated: "Roles assigned here are ignored by Keycloak, use keycloak_group_role_mapping instead",
 			},
 			"clientroles": {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:       schema.TypeMap,
+				Optional:   true,
+				Elem:       &schema.Schema{Type: schema.TypeString},
+				Deprecated: "Roles assigned here are ignored by Keycloak, use keycloak_group_role_mapping instead",
 			},
 			"subgroups": {
-				Type:     schema.TypeList,
+				Type:       schema.TypeList,
+				Optional:   true,
+				Elem:       &schema.Schema{Type: schema.TypeString},
+				Deprecated: "Subgroups are created by setting parent_id on the child group",
+			},
+			// Groups without a parent are created at the top level. Changing the parent moves the group.
+			"parent_id": {
+				Type:     schema.TypeString,
 				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 		},
 	}
 }
 
+// Groups can be imported by ID ('${realm}.${group_id}') or by path ('${realm}./parent/child').
 func importGroupHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	realm, id, err := splitRealmId(d.Id())
 	if err != nil {
 		return nil, err
 	}
 
+	if strings.HasPrefix(id, "/") {
+		c := m.(*keycloak.KeycloakClient)
+		group, err := c.GetGroupByPath(id, realm)
+		if err != nil {
+			return nil, err
+		}
+
+		id = group.Id
+	}
+
 	d.SetId(id)
 	d.Set("realm", realm)
 
@@ -73,11 +99,17 @@ func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
 
 	group, err := c.GetGroup(d.Id(), realm(d))
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	groupToResourceData(group, d)
 
+	parentId, err := c.GetGroupParentId(group, realm(d))
+	if err != nil {
+		return err
+	}
+	d.Set("parent_id", parentId)
+
 	return nil
 }
 
@@ -85,7 +117,14 @@ func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
 
 	apiGroup := m.(*keycloak.KeycloakClient)
 	group := resourceDataToGroup(d)
-	created, err := apiGroup.AddGroup(&group, realm(d))
+
+	var created *keycloak.Group
+	var err error
+	if parentId := d.Get("parent_id").(string); parentId != "" {
+		created, err = apiGroup.AddChildGroup(&group, parentId, realm(d))
+	} else {
+		created, err = apiGroup.AddGroup(&group, realm(d))
+	}
 
 	if err != nil {
 		return err
@@ -97,9 +136,22 @@ func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	user := resourceDataToGroup(d)
+	group := resourceDataToGroup(d)
 	apiGroup := m.(*keycloak.KeycloakClient)
-	return apiGroup.UpdateGroup(&user, realm(d))
+
+	err := apiGroup.UpdateGroup(&group, realm(d))
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("parent_id") {
+		err = apiGroup.MoveGroup(&group, d.Get("parent_id").(string), realm(d))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceGroupRead(d, m)
 }
 
 func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
@@ -107,7 +159,6 @@ func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
 	return apiGroup.DeleteGroup(d.Id(), realm(d))
 }
 
-//TODO: Support subgroups, might have to make API calls to read based on name
 func resourceDataToGroup(d *schema.ResourceData) keycloak.Group {
 	u := keycloak.Group{
 		Name:        d.Get("name").(string),
@@ -123,7 +174,6 @@ func resourceDataToGroup(d *schema.ResourceData) keycloak.Group {
 	return u
 }
 
-//TODO: Support subgroups
 func groupToResourceData(g *keycloak.Group, d *schema.ResourceData) {
 	d.SetId(g.Id)
 	d.Set("id", g.Id)
@@ -131,5 +181,5 @@ func groupToResourceData(g *keycloak.Group, d *schema.ResourceData) {
 	d.Set("attributes", g.Attributes)
 	d.Set("realmroles", g.RealmRoles)
 	d.Set("clientroles", g.ClientRoles)
-	//d.Set("subgroups", u.SubGroups)
+	d.Set("path", g.Path)
 }
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// Manages all roles that are directly assigned to a group, so only one of these resources
+// should exist per group.
+func resourceGroupRoleMapping() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceGroupRoleMappingRead),
+		Create: schema.CreateFunc(resourceGroupRoleMappingCreate),
+		Update: schema.UpdateFunc(resourceGroupRoleMappingUpdate),
+		Delete: schema.DeleteFunc(resourceGroupRoleMappingDelete),
+
+		// Group role mappings are importable by group ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importGroupRoleMappingHelper,
+		},
+
+		Schema: mergeSchemas(roleMappingSchema(), map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+	}
+}
+
+func importGroupRoleMappingHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, groupId, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(groupId)
+	d.Set("realm", realm)
+	d.Set("group_id", groupId)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGroupRoleMappingRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	d.Set("group_id", d.Id())
+	return readRoleMappings(groupRoleMappingTarget(apiClient, realm(d), d.Id()), d, unmanagedRoles{})
+}
+
+func resourceGroupRoleMappingCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("group_id").(string))
+
+	return resourceGroupRoleMappingUpdate(d, m)
+}
+
+func resourceGroupRoleMappingUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := updateRoleMappings(apiClient, groupRoleMappingTarget(apiClient, realm(d), d.Id()), d)
+	if err != nil {
+		return err
+	}
+
+	return resourceGroupRoleMappingRead(d, m)
+}
+
+func resourceGroupRoleMappingDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return deleteRoleMappings(apiClient, groupRoleMappingTarget(apiClient, realm(d), d.Id()), d)
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Adds every user that logs in with the identity provider to a group.
+func resourceHardcodedGroupIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: fixedIdentityProviderMapper("oidc-hardcoded-group-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			// The path of the group, i.e. the 'path' attribute of keycloak_group
+			"group": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["group"] = d.Get("group").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("group", config["group"])
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Assigns a role to every user that logs in with the identity provider.
+func resourceHardcodedRoleIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: fixedIdentityProviderMapper("hardcoded-role-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			// The name of a realm role, or '${client_id}.${role_name}' for client roles
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["role"] = d.Get("role").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("role", config["role"])
+		},
+	})
+}
@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Identity providers of any type that authenticate with client credentials, e.g. the social login providers
+// 'github', 'google' or 'microsoft'. Provider specific settings (such as the tenant of 'microsoft') are set
+// through 'extra_config'.
+func resourceGenericIdentityProvider() *schema.Resource {
+	return identityProviderResource(identityProviderType{
+		schema: mergeSchemas(identityProviderClientSchema(), map[string]*schema.Schema{
+			"provider_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}),
+		toConfig:   identityProviderClientToConfig,
+		fromConfig: identityProviderClientFromConfig,
+	})
+}
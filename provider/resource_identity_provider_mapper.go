@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// An identity provider mapper of any type, configured through the raw config map. The typed mapper
+// resources should be preferred where they exist.
+func resourceIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		schema: map[string]*schema.Schema{
+			// The Keycloak mapper type, e.g. 'github-user-attribute-mapper'
+			"identity_provider_mapper": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			for key, value := range getOptionalStringMap(d, "config") {
+				config[key] = value
+			}
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			rawConfig := map[string]string{}
+			for key, value := range config {
+				if key != "syncMode" {
+					rawConfig[key] = value
+				}
+			}
+			d.Set("config", rawConfig)
+		},
+	})
+}
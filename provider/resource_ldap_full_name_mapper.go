@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Maps a single LDAP attribute, usually 'cn', to the first and last name of users.
+func resourceLdapFullNameMapper() *schema.Resource {
+	return ldapMapperResource(ldapMapperType{
+		providerId: "full-name-ldap-mapper",
+		schema: map[string]*schema.Schema{
+			"ldap_full_name_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Only writes the full name to LDAP, first and last name are then read from other attributes.
+			"write_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return map[string]string{
+				"ldap.full.name.attribute": d.Get("ldap_full_name_attribute").(string),
+				"read.only":                strconv.FormatBool(d.Get("read_only").(bool)),
+				"write.only":               strconv.FormatBool(d.Get("write_only").(bool)),
+			}
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("ldap_full_name_attribute", config["ldap.full.name.attribute"])
+			d.Set("read_only", parseConfigBool(config["read.only"]))
+			d.Set("write_only", parseConfigBool(config["write.only"]))
+		},
+	})
+}
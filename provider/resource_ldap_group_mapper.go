@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Maps LDAP groups to Keycloak groups and their members to group memberships.
+func resourceLdapGroupMapper() *schema.Resource {
+	return ldapMapperResource(ldapMapperType{
+		providerId: "group-ldap-mapper",
+		schema: mergeSchemas(ldapMembershipSchema(), map[string]*schema.Schema{
+			"ldap_groups_dn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_name_ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_object_classes": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Additional LDAP filter for groups, must start with '(' and end with ')'
+			"groups_ldap_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Whether nested LDAP groups are mapped to subgroups
+			"preserve_group_inheritance": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ignore_missing_groups": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// LDAP attributes of groups that are mapped to attributes of the Keycloak groups
+			"mapped_group_attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"drop_non_existing_groups_during_sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"user_roles_retrieve_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "LOAD_GROUPS_BY_MEMBER_ATTRIBUTE",
+				ValidateFunc: validation.StringInSlice([]string{
+					"LOAD_GROUPS_BY_MEMBER_ATTRIBUTE",
+					"GET_GROUPS_FROM_USER_MEMBEROF_ATTRIBUTE",
+					"LOAD_GROUPS_BY_MEMBER_ATTRIBUTE_RECURSIVELY",
+				}, false),
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return ldapMembershipToConfig(d, map[string]string{
+				"groups.dn":                            d.Get("ldap_groups_dn").(string),
+				"group.name.ldap.attribute":            d.Get("group_name_ldap_attribute").(string),
+				"group.object.classes":                 joinCommaSeparated(getMandatoryStringList(d, "group_object_classes")),
+				"groups.ldap.filter":                   d.Get("groups_ldap_filter").(string),
+				"preserve.group.inheritance":           strconv.FormatBool(d.Get("preserve_group_inheritance").(bool)),
+				"ignore.missing.groups":                strconv.FormatBool(d.Get("ignore_missing_groups").(bool)),
+				"mapped.group.attributes":              joinCommaSeparated(getOptionalStringList(d, "mapped_group_attributes")),
+				"drop.non.existing.groups.during.sync": strconv.FormatBool(d.Get("drop_non_existing_groups_during_sync").(bool)),
+				"user.roles.retrieve.strategy":         d.Get("user_roles_retrieve_strategy").(string),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("ldap_groups_dn", config["groups.dn"])
+			d.Set("group_name_ldap_attribute", config["group.name.ldap.attribute"])
+			d.Set("group_object_classes", splitCommaSeparated(config["group.object.classes"]))
+			d.Set("groups_ldap_filter", config["groups.ldap.filter"])
+			d.Set("preserve_group_inheritance", parseConfigBool(config["preserve.group.inheritance"]))
+			d.Set("ignore_missing_groups", parseConfigBool(config["ignore.missing.groups"]))
+			d.Set("mapped_group_attributes", splitCommaSeparated(config["mapped.group.attributes"]))
+			d.Set("drop_non_existing_groups_during_sync", parseConfigBool(config["drop.non.existing.groups.during.sync"]))
+			d.Set("user_roles_retrieve_strategy", config["user.roles.retrieve.strategy"])
+			ldapMembershipFromConfig(config, d)
+		},
+	})
+}
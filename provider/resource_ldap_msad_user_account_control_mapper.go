@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Maps the 'userAccountControl' attribute of Active Directory, e.g. to disable users whose account is
+// disabled in Active Directory and to require a password update when it has expired.
+func resourceLdapMsadUserAccountControlMapper() *schema.Resource {
+	return ldapMapperResource(ldapMapperType{
+		providerId: "msad-user-account-control-mapper",
+		schema: map[string]*schema.Schema{
+			// Shows the password policy hints of Active Directory when a password change is rejected
+			"ldap_password_policy_hints_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return map[string]string{
+				"ldap.password.policy.hints.enabled": strconv.FormatBool(d.Get("ldap_password_policy_hints_enabled").(bool)),
+			}
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("ldap_password_policy_hints_enabled", parseConfigBool(config["ldap.password.policy.hints.enabled"]))
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Maps LDAP entries, usually groups, to realm or client roles and their members to role assignments.
+func resourceLdapRoleMapper() *schema.Resource {
+	return ldapMapperResource(ldapMapperType{
+		providerId: "role-ldap-mapper",
+		schema: mergeSchemas(ldapMembershipSchema(), map[string]*schema.Schema{
+			"ldap_roles_dn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role_name_ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"role_object_classes": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// Additional LDAP filter for roles, must start with '(' and end with ')'
+			"roles_ldap_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// The human-readable client ID of the client the roles are mapped to. Roles are mapped to
+			// realm roles if this is not set.
+			"client_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"user_roles_retrieve_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "LOAD_ROLES_BY_MEMBER_ATTRIBUTE",
+				ValidateFunc: validation.StringInSlice([]string{
+					"LOAD_ROLES_BY_MEMBER_ATTRIBUTE",
+					"GET_ROLES_FROM_USER_MEMBEROF_ATTRIBUTE",
+					"LOAD_ROLES_BY_MEMBER_ATTRIBUTE_RECURSIVELY",
+				}, false),
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return ldapMembershipToConfig(d, map[string]string{
+				"roles.dn":                     d.Get("ldap_roles_dn").(string),
+				"role.name.ldap.attribute":     d.Get("role_name_ldap_attribute").(string),
+				"role.object.classes":          joinCommaSeparated(getMandatoryStringList(d, "role_object_classes")),
+				"roles.ldap.filter":            d.Get("roles_ldap_filter").(string),
+				"use.realm.roles.mapping":      strconv.FormatBool(clientId(d) == ""),
+				"client.id":                    clientId(d),
+				"user.roles.retrieve.strategy": d.Get("user_roles_retrieve_strategy").(string),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("ldap_roles_dn", config["roles.dn"])
+			d.Set("role_name_ldap_attribute", config["role.name.ldap.attribute"])
+			d.Set("role_object_classes", splitCommaSeparated(config["role.object.classes"]))
+			d.Set("roles_ldap_filter", config["roles.ldap.filter"])
+			d.Set("client_id", config["client.id"])
+			d.Set("user_roles_retrieve_strategy", config["user.roles.retrieve.strategy"])
+			ldapMembershipFromConfig(config, d)
+		},
+	})
+}
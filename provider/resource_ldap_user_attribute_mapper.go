@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Maps an LDAP attribute to a user attribute or to one of the user's built-in properties, e.g. 'email'.
+func resourceLdapUserAttributeMapper() *schema.Resource {
+	return ldapMapperResource(ldapMapperType{
+		providerId: "user-attribute-ldap-mapper",
+		schema: map[string]*schema.Schema{
+			"user_model_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"always_read_value_from_ldap": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"is_mandatory_in_ldap": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return map[string]string{
+				"user.model.attribute":        d.Get("user_model_attribute").(string),
+				"ldap.attribute":              d.Get("ldap_attribute").(string),
+				"read.only":                   strconv.FormatBool(d.Get("read_only").(bool)),
+				"always.read.value.from.ldap": strconv.FormatBool(d.Get("always_read_value_from_ldap").(bool)),
+				"is.mandatory.in.ldap":        strconv.FormatBool(d.Get("is_mandatory_in_ldap").(bool)),
+			}
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("user_model_attribute", config["user.model.attribute"])
+			d.Set("ldap_attribute", config["ldap.attribute"])
+			d.Set("read_only", parseConfigBool(config["read.only"]))
+			d.Set("always_read_value_from_ldap", parseConfigBool(config["always.read.value.from.ldap"]))
+			d.Set("is_mandatory_in_ldap", parseConfigBool(config["is.mandatory.in.ldap"]))
+		},
+	})
+}
@@ -0,0 +1,346 @@
+// This file provides a Terraform resource for LDAP user federation providers, which are Keycloak components.
+// The component resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_componentrepresentation
+
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+const userStorageProviderType = "org.keycloak.storage.UserStorageProvider"
+
+func resourceLdapUserFederation() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceLdapUserFederationRead),
+		Create: schema.CreateFunc(resourceLdapUserFederationCreate),
+		Update: schema.UpdateFunc(resourceLdapUserFederationUpdate),
+		Delete: schema.DeleteFunc(resourceLdapUserFederationDelete),
+
+		// LDAP user federation providers are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importLdapUserFederationHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// User federation providers are queried in the order of their priority, lowest first.
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			// Whether users are imported into Keycloak's database or only read from LDAP
+			"import_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// 'WRITABLE' writes changes back to LDAP, 'UNSYNCED' keeps them in Keycloak only.
+			"edit_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "READ_ONLY",
+				ValidateFunc: validation.StringInSlice([]string{"READ_ONLY", "WRITABLE", "UNSYNCED"}, false),
+			},
+			// Whether users registered in Keycloak are created in LDAP
+			"sync_registrations": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"vendor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "other",
+				ValidateFunc: validation.StringInSlice([]string{"other", "ad", "rhds", "tivoli", "edirectory"}, false),
+			},
+			"username_ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"rdn_ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"uuid_ldap_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_object_classes": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"users_dn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Keycloak binds anonymously if no bind DN is set. The credential can not be read from Keycloak.
+			"bind_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bind_credential": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			// Additional LDAP filter for users, must start with '(' and end with ')'
+			"custom_user_search_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"search_scope": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ONE_LEVEL",
+				ValidateFunc: validation.StringInSlice([]string{"ONE_LEVEL", "SUBTREE"}, false),
+			},
+			"start_tls": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Whether the truststore configured for Keycloak is used for LDAP connections
+			"use_truststore_spi": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ldapsOnly",
+				ValidateFunc: validation.StringInSlice([]string{"ldapsOnly", "always", "never"}, false),
+			},
+			// Timeouts in milliseconds, Keycloak uses the JNDI defaults if these are not set.
+			"connection_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"read_timeout": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"pagination": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"batch_size_for_sync": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  1000,
+			},
+			// Sync periods in seconds, periodic syncs are disabled if these are not set.
+			"full_sync_period": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+			},
+			"changed_sync_period": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+			},
+			"cache_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "DEFAULT",
+				ValidateFunc: validation.StringInSlice([]string{"DEFAULT", "EVICT_DAILY", "EVICT_WEEKLY", "MAX_LIFESPAN", "NO_CACHE"}, false),
+			},
+		},
+	}
+}
+
+// Keycloak stores the search scope as the number of the corresponding JNDI constant.
+var ldapSearchScopes = map[string]string{
+	"ONE_LEVEL": "1",
+	"SUBTREE":   "2",
+}
+
+func importLdapUserFederationHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceLdapUserFederationRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	component, err := apiClient.GetComponent(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	ldapUserFederationToResourceData(component, d)
+	return nil
+}
+
+func resourceLdapUserFederationCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	// User federation providers belong to the realm, which is referenced by its internal ID.
+	r, err := apiClient.GetRealm(realm(d))
+	if err != nil {
+		return err
+	}
+
+	component := resourceDataToLdapUserFederation(d)
+	component.ParentId = r.Id
+
+	created, err := apiClient.CreateComponent(realm(d), component)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceLdapUserFederationRead(d, m)
+}
+
+func resourceLdapUserFederationUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	current, err := apiClient.GetComponent(realm(d), d.Id())
+	if err != nil {
+		return err
+	}
+
+	component := resourceDataToLdapUserFederation(d)
+	component.ParentId = current.ParentId
+
+	err = apiClient.UpdateComponent(realm(d), component)
+	if err != nil {
+		return err
+	}
+
+	return resourceLdapUserFederationRead(d, m)
+}
+
+func resourceLdapUserFederationDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteComponent(realm(d), d.Id())
+}
+
+func resourceDataToLdapUserFederation(d *schema.ResourceData) *keycloak.Component {
+	authType := "none"
+	if d.Get("bind_dn").(string) != "" {
+		authType = "simple"
+	}
+
+	config := map[string]string{
+		"enabled":                strconv.FormatBool(d.Get("enabled").(bool)),
+		"priority":               strconv.Itoa(d.Get("priority").(int)),
+		"importEnabled":          strconv.FormatBool(d.Get("import_enabled").(bool)),
+		"editMode":               d.Get("edit_mode").(string),
+		"syncRegistrations":      strconv.FormatBool(d.Get("sync_registrations").(bool)),
+		"vendor":                 d.Get("vendor").(string),
+		"usernameLDAPAttribute":  d.Get("username_ldap_attribute").(string),
+		"rdnLDAPAttribute":       d.Get("rdn_ldap_attribute").(string),
+		"uuidLDAPAttribute":      d.Get("uuid_ldap_attribute").(string),
+		"userObjectClasses":      joinCommaSeparated(getMandatoryStringList(d, "user_object_classes")),
+		"connectionUrl":          d.Get("connection_url").(string),
+		"usersDn":                d.Get("users_dn").(string),
+		"authType":               authType,
+		"bindDn":                 d.Get("bind_dn").(string),
+		"bindCredential":         d.Get("bind_credential").(string),
+		"customUserSearchFilter": d.Get("custom_user_search_filter").(string),
+		"searchScope":            ldapSearchScopes[d.Get("search_scope").(string)],
+		"startTls":               strconv.FormatBool(d.Get("start_tls").(bool)),
+		"useTruststoreSpi":       d.Get("use_truststore_spi").(string),
+		"pagination":             strconv.FormatBool(d.Get("pagination").(bool)),
+		"batchSizeForSync":       strconv.Itoa(d.Get("batch_size_for_sync").(int)),
+		"fullSyncPeriod":         strconv.Itoa(d.Get("full_sync_period").(int)),
+		"changedSyncPeriod":      strconv.Itoa(d.Get("changed_sync_period").(int)),
+		"cachePolicy":            d.Get("cache_policy").(string),
+		"connectionTimeout":      "",
+		"readTimeout":            "",
+	}
+
+	if timeout := d.Get("connection_timeout").(int); timeout > 0 {
+		config["connectionTimeout"] = strconv.Itoa(timeout)
+	}
+
+	if timeout := d.Get("read_timeout").(int); timeout > 0 {
+		config["readTimeout"] = strconv.Itoa(timeout)
+	}
+
+	c := keycloak.Component{
+		Name:         d.Get("name").(string),
+		ProviderId:   "ldap",
+		ProviderType: userStorageProviderType,
+		Config:       toComponentConfig(config),
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return &c
+}
+
+func ldapUserFederationToResourceData(c *keycloak.Component, d *schema.ResourceData) {
+	config := fromComponentConfig(c.Config)
+
+	d.Set("name", c.Name)
+	d.Set("enabled", parseConfigBool(config["enabled"]))
+	d.Set("import_enabled", parseConfigBool(config["importEnabled"]))
+	d.Set("edit_mode", config["editMode"])
+	d.Set("sync_registrations", parseConfigBool(config["syncRegistrations"]))
+	d.Set("vendor", config["vendor"])
+	d.Set("username_ldap_attribute", config["usernameLDAPAttribute"])
+	d.Set("rdn_ldap_attribute", config["rdnLDAPAttribute"])
+	d.Set("uuid_ldap_attribute", config["uuidLDAPAttribute"])
+	d.Set("user_object_classes", splitCommaSeparated(config["userObjectClasses"]))
+	d.Set("connection_url", config["connectionUrl"])
+	d.Set("users_dn", config["usersDn"])
+	d.Set("bind_dn", config["bindDn"])
+	d.Set("custom_user_search_filter", config["customUserSearchFilter"])
+	d.Set("start_tls", parseConfigBool(config["startTls"]))
+	d.Set("use_truststore_spi", config["useTruststoreSpi"])
+	d.Set("pagination", parseConfigBool(config["pagination"]))
+	d.Set("cache_policy", config["cachePolicy"])
+
+	for scope, value := range ldapSearchScopes {
+		if config["searchScope"] == value {
+			d.Set("search_scope", scope)
+		}
+	}
+
+	ints := map[string]string{
+		"priority":            "priority",
+		"connection_timeout":  "connectionTimeout",
+		"read_timeout":        "readTimeout",
+		"batch_size_for_sync": "batchSizeForSync",
+		"full_sync_period":    "fullSyncPeriod",
+		"changed_sync_period": "changedSyncPeriod",
+	}
+	for key, configKey := range ints {
+		value, _ := strconv.Atoi(config[configKey])
+		d.Set(key, value)
+	}
+}
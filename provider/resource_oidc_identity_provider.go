@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Identity providers that implement OpenID Connect, e.g. Azure AD or another Keycloak.
+func resourceOidcIdentityProvider() *schema.Resource {
+	return identityProviderResource(identityProviderType{
+		providerId: "oidc",
+		schema: mergeSchemas(identityProviderClientSchema(), map[string]*schema.Schema{
+			"authorization_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"token_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_info_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logout_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"issuer": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Signatures of ID tokens are validated with the keys published at this URL.
+			"jwks_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"validate_signature": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"client_auth_method": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "client_secret_post",
+				ValidateFunc: validation.StringInSlice([]string{"client_secret_post", "client_secret_basic", "client_secret_jwt", "private_key_jwt"}, false),
+			},
+			"backchannel_supported": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"pkce_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		}),
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			identityProviderClientToConfig(d, config)
+			config["authorizationUrl"] = d.Get("authorization_url").(string)
+			config["tokenUrl"] = d.Get("token_url").(string)
+			config["userInfoUrl"] = d.Get("user_info_url").(string)
+			config["logoutUrl"] = d.Get("logout_url").(string)
+			config["issuer"] = d.Get("issuer").(string)
+			config["jwksUrl"] = d.Get("jwks_url").(string)
+			config["useJwksUrl"] = strconv.FormatBool(d.Get("jwks_url").(string) != "")
+			config["validateSignature"] = strconv.FormatBool(d.Get("validate_signature").(bool))
+			config["clientAuthMethod"] = d.Get("client_auth_method").(string)
+			config["backchannelSupported"] = strconv.FormatBool(d.Get("backchannel_supported").(bool))
+			config["pkceEnabled"] = strconv.FormatBool(d.Get("pkce_enabled").(bool))
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			identityProviderClientFromConfig(config, d)
+			d.Set("authorization_url", config["authorizationUrl"])
+			d.Set("token_url", config["tokenUrl"])
+			d.Set("user_info_url", config["userInfoUrl"])
+			d.Set("logout_url", config["logoutUrl"])
+			d.Set("issuer", config["issuer"])
+			d.Set("jwks_url", config["jwksUrl"])
+			d.Set("validate_signature", parseConfigBool(config["validateSignature"]))
+			d.Set("client_auth_method", config["clientAuthMethod"])
+			d.Set("backchannel_supported", parseConfigBool(config["backchannelSupported"]))
+			d.Set("pkce_enabled", parseConfigBool(config["pkceEnabled"]))
+		},
+	})
+}
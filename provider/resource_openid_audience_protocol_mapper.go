@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Adds a client or a custom value to the audience ('aud' claim) of issued tokens.
+func resourceOpenIdAudienceProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       openIdConnectProtocol,
+		protocolMapper: "oidc-audience-mapper",
+		schema: map[string]*schema.Schema{
+			// The (human-readable) client ID of the client to add to the audience
+			"included_client_audience": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"included_custom_audience"},
+			},
+			"included_custom_audience": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"included_client_audience"},
+			},
+			"add_to_id_token": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"add_to_access_token": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return map[string]string{
+				"included.client.audience": d.Get("included_client_audience").(string),
+				"included.custom.audience": d.Get("included_custom_audience").(string),
+				"id.token.claim":           strconv.FormatBool(d.Get("add_to_id_token").(bool)),
+				"access.token.claim":       strconv.FormatBool(d.Get("add_to_access_token").(bool)),
+			}
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("included_client_audience", config["included.client.audience"])
+			d.Set("included_custom_audience", config["included.custom.audience"])
+			d.Set("add_to_id_token", parseConfigBool(config["id.token.claim"]))
+			d.Set("add_to_access_token", parseConfigBool(config["access.token.claim"]))
+		},
+		validate: func(d *schema.ResourceData) error {
+			if d.Get("included_client_audience").(string) == "" && d.Get("included_custom_audience").(string) == "" {
+				return fmt.Errorf("One of included_client_audience or included_custom_audience must be set")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,131 @@
+// This file provides a Terraform resource for Keycloak client scopes using the OpenID Connect protocol
+// The client scope resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_clientscoperepresentation
+
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceOpenIdClientScope() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceOpenIdClientScopeRead),
+		Create: schema.CreateFunc(resourceOpenIdClientScopeCreate),
+		Update: schema.UpdateFunc(resourceOpenIdClientScopeUpdate),
+		Delete: schema.DeleteFunc(resourceOpenIdClientScopeDelete),
+
+		// Client scopes are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importOpenIdClientScopeHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The name is also the value of the 'scope' parameter that requests this scope.
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Shown to users when a client that requires consent requests this scope. The consent
+			// screen lists the scope by name if this is not set.
+			"consent_screen_text": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Whether the scope's name is added to the 'scope' claim of access tokens.
+			"include_in_token_scope": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+func importOpenIdClientScopeHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceOpenIdClientScopeRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	scope, err := apiClient.GetClientScope(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("name", scope.Name)
+	d.Set("description", scope.Description)
+	d.Set("consent_screen_text", scope.Attributes["consent.screen.text"])
+	d.Set("include_in_token_scope", parseConfigBool(scope.Attributes["include.in.token.scope"]))
+
+	return nil
+}
+
+func resourceOpenIdClientScopeCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateClientScope(realm(d), resourceDataToOpenIdClientScope(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceOpenIdClientScopeRead(d, m)
+}
+
+func resourceOpenIdClientScopeUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateClientScope(realm(d), resourceDataToOpenIdClientScope(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceOpenIdClientScopeRead(d, m)
+}
+
+func resourceOpenIdClientScopeDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteClientScope(realm(d), d.Id())
+}
+
+func resourceDataToOpenIdClientScope(d *schema.ResourceData) *keycloak.ClientScope {
+	s := keycloak.ClientScope{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Protocol:    openIdConnectProtocol,
+		Attributes: map[string]string{
+			"display.on.consent.screen": "true",
+			"consent.screen.text":       d.Get("consent_screen_text").(string),
+			"include.in.token.scope":    strconv.FormatBool(d.Get("include_in_token_scope").(bool)),
+		},
+	}
+
+	if !d.IsNewResource() {
+		s.Id = d.Id()
+	}
+
+	return &s
+}
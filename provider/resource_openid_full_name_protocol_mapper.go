@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Adds the user's full name as the 'name' claim to issued tokens.
+func resourceOpenIdFullNameProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       openIdConnectProtocol,
+		protocolMapper: "oidc-full-name-mapper",
+		schema:         tokenClaimSchema(),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return tokenClaimsToConfig(d, map[string]string{})
+		},
+		fromConfig: tokenClaimsFromConfig,
+	})
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Adds the groups of a user as a claim to issued tokens.
+func resourceOpenIdGroupMembershipProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       openIdConnectProtocol,
+		protocolMapper: "oidc-group-membership-mapper",
+		schema: mergeSchemas(tokenClaimSchema(), map[string]*schema.Schema{
+			"claim_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Use full group paths ('/parent/child') instead of group names
+			"full_path": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return tokenClaimsToConfig(d, map[string]string{
+				"claim.name": d.Get("claim_name").(string),
+				"full.path":  strconv.FormatBool(d.Get("full_path").(bool)),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("claim_name", config["claim.name"])
+			d.Set("full_path", parseConfigBool(config["full.path"]))
+			tokenClaimsFromConfig(config, d)
+		},
+	})
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Adds a claim with a fixed value to issued tokens.
+func resourceOpenIdHardcodedClaimProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       openIdConnectProtocol,
+		protocolMapper: "oidc-hardcoded-claim-mapper",
+		schema: mergeSchemas(tokenClaimSchema(), map[string]*schema.Schema{
+			"claim_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_value_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "String",
+				ValidateFunc: validation.StringInSlice(claimValueTypes, false),
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return tokenClaimsToConfig(d, map[string]string{
+				"claim.name":     d.Get("claim_name").(string),
+				"claim.value":    d.Get("claim_value").(string),
+				"jsonType.label": d.Get("claim_value_type").(string),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("claim_name", config["claim.name"])
+			d.Set("claim_value", config["claim.value"])
+			d.Set("claim_value_type", config["jsonType.label"])
+			tokenClaimsFromConfig(config, d)
+		},
+	})
+}
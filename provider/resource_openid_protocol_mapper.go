@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// A protocol mapper of any type, configured through the raw config map. The typed mapper
+// resources should be preferred where they exist.
+func resourceOpenIdProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol: openIdConnectProtocol,
+		schema: map[string]*schema.Schema{
+			// The Keycloak mapper type, e.g. 'oidc-usersessionmodel-note-mapper'
+			"protocol_mapper": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"config": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return getOptionalStringMap(d, "config")
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("config", config)
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Adds the value of a user attribute as a claim to issued tokens.
+func resourceOpenIdUserAttributeProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       openIdConnectProtocol,
+		protocolMapper: "oidc-usermodel-attribute-mapper",
+		schema: mergeSchemas(tokenClaimSchema(), map[string]*schema.Schema{
+			"user_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"claim_value_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "String",
+				ValidateFunc: validation.StringInSlice(claimValueTypes, false),
+			},
+			"multivalued": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return tokenClaimsToConfig(d, map[string]string{
+				"user.attribute": d.Get("user_attribute").(string),
+				"claim.name":     d.Get("claim_name").(string),
+				"jsonType.label": d.Get("claim_value_type").(string),
+				"multivalued":    strconv.FormatBool(d.Get("multivalued").(bool)),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("user_attribute", config["user.attribute"])
+			d.Set("claim_name", config["claim.name"])
+			d.Set("claim_value_type", config["jsonType.label"])
+			d.Set("multivalued", parseConfigBool(config["multivalued"]))
+			tokenClaimsFromConfig(config, d)
+		},
+	})
+}
+
+// The JSON types that claim values can be converted to.
+var claimValueTypes = []string{"String", "long", "int", "boolean", "JSON"}
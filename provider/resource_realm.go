@@ -2,8 +2,10 @@ package provider
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/tazjin/terraform-provider-keycloak/keycloak"
 )
 
@@ -53,10 +55,56 @@ func resourceRealm() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			// Removing this block removes the SMTP server configuration from the realm.
 			"smtp_server": {
-				Type:             schema.TypeMap,
-				Optional:         true,
-				DiffSuppressFunc: ignoreSmtpPasswordChange,
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: smtpServerSchema(),
+				},
+			},
+			// The realm's current password policy is kept if this block is omitted. An empty block removes all
+			// password policies from the realm.
+			"password_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: passwordPolicySchema(),
+				},
+			},
+
+			// Keycloak resets the OTP and WebAuthn policies on every realm update unless they are sent. If these blocks
+			// are omitted, new realms use Keycloak's defaults and the policies of existing realms are sent back from
+			// the state, so that they are kept.
+			"otp_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: otpPolicySchema(),
+				},
+			},
+			"web_authn_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: webAuthnPolicySchema(),
+				},
+			},
+			"web_authn_passwordless_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: webAuthnPolicySchema(),
+				},
 			},
 
 			"internationalization_enabled": {
@@ -91,6 +139,34 @@ func resourceRealm() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			// Aliases of the authentication flows bound to the realm. Keycloak's built-in flows are
+			// used if these are not set. Removing them from the configuration keeps the current binding, the
+			// built-in flows (e.g. 'browser') have to be set explicitly to restore them.
+			"browser_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"registration_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"direct_grant_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"reset_credentials_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"client_authentication_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
 			"access_token_lifespan": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -181,19 +257,6 @@ func resourceRealm() *schema.Resource {
 	}
 }
 
-// Keycloak returns some asterisks instead of the plaintext password when querying for the realm configuration.
-// Due to this Terraform will assume a change has happened and attempt to reset the password.
-// This function will ignore the planned change in such a case, but it will also currently make it impossible to
-// change the password (because it's not persisted in the state).
-func ignoreSmtpPasswordChange(k, old, new string, d *schema.ResourceData) bool {
-	if k == "smtp_server.password" && old == "**********" {
-		// It would be nice to print a warning here, but it's unclear how/if providers can output things.
-		return true
-	}
-
-	return false
-}
-
 func validateSslRequired(v interface{}, _ string) (w []string, err []error) {
 	switch v.(string) {
 	case
@@ -213,7 +276,7 @@ func resourceRealmRead(d *schema.ResourceData, m interface{}) error {
 
 	r, err := c.GetRealm(d.Id())
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	realmToResourceData(r, d)
@@ -262,6 +325,12 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		EmailTheme:   d.Get("email_theme").(string),
 		LoginTheme:   d.Get("login_theme").(string),
 
+		BrowserFlow:              d.Get("browser_flow").(string),
+		RegistrationFlow:         d.Get("registration_flow").(string),
+		DirectGrantFlow:          d.Get("direct_grant_flow").(string),
+		ResetCredentialsFlow:     d.Get("reset_credentials_flow").(string),
+		ClientAuthenticationFlow: d.Get("client_authentication_flow").(string),
+
 		InternationalizationEnabled: getOptionalBool(d, "internationalization_enabled"),
 		RegistrationAllowed:         getOptionalBool(d, "registration_allowed"),
 		RegistrationEmailAsUsername: getOptionalBool(d, "registration_email_as_username"),
@@ -293,9 +362,19 @@ func resourceDataToRealm(d *schema.ResourceData) *keycloak.Realm {
 		r.Id = r.Realm
 	}
 
-	if smtpMap, present := d.GetOk("smtp_server"); present {
-		smtp := keycloak.SmtpServer(smtpMap.(map[string]interface{}))
-		r.SmtpServer = &smtp
+	r.SmtpServer = getSmtpServer(d)
+
+	if p := getPasswordPolicy(d); p != nil {
+		passwordPolicy := p.String()
+		r.PasswordPolicy = &passwordPolicy
+	}
+
+	getOtpPolicy(d, &r)
+	if p := getWebAuthnPolicy(d, "web_authn_policy"); p != nil {
+		r.WebAuthnPolicy = *p
+	}
+	if p := getWebAuthnPolicy(d, "web_authn_passwordless_policy"); p != nil {
+		r.WebAuthnPasswordlessPolicy = keycloak.WebAuthnPasswordlessPolicy(*p)
 	}
 
 	return &r
@@ -316,10 +395,22 @@ func realmToResourceData(r *keycloak.Realm, d *schema.ResourceData) {
 	d.Set("email_theme", r.EmailTheme)
 	d.Set("login_theme", r.LoginTheme)
 
-	if r.SmtpServer != nil {
-		d.Set("smtp_server", *r.SmtpServer)
+	d.Set("browser_flow", r.BrowserFlow)
+	d.Set("registration_flow", r.RegistrationFlow)
+	d.Set("direct_grant_flow", r.DirectGrantFlow)
+	d.Set("reset_credentials_flow", r.ResetCredentialsFlow)
+	d.Set("client_authentication_flow", r.ClientAuthenticationFlow)
+
+	setSmtpServer(d, r.SmtpServer)
+
+	if r.PasswordPolicy != nil {
+		setPasswordPolicy(d, keycloak.ParsePasswordPolicy(*r.PasswordPolicy))
 	}
 
+	setOtpPolicy(d, r)
+	setWebAuthnPolicy(d, "web_authn_policy", r.WebAuthnPolicy)
+	setWebAuthnPolicy(d, "web_authn_passwordless_policy", keycloak.WebAuthnPolicy(r.WebAuthnPasswordlessPolicy))
+
 	setOptionalBool(d, "internationalization_enabled", r.InternationalizationEnabled)
 	setOptionalBool(d, "registration_allowed", r.RegistrationAllowed)
 	setOptionalBool(d, "registration_email_as_username", r.RegistrationEmailAsUsername)
@@ -344,3 +435,457 @@ func realmToResourceData(r *keycloak.Realm, d *schema.ResourceData) {
 	setOptionalInt(d, "max_delta_time_seconds", r.MaxDeltaTimeSeconds)
 	setOptionalInt(d, "failure_factor", r.FailureFactor)
 }
+
+// Policies that are not set (or set to zero) are not included in the realm's password policy.
+func passwordPolicySchema() map[string]*schema.Schema {
+	intPolicy := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:  description,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		}
+	}
+
+	return map[string]*schema.Schema{
+		"length":                        intPolicy("Minimum password length"),
+		"max_length":                    intPolicy("Maximum password length"),
+		"digits":                        intPolicy("Minimum number of digits"),
+		"lower_case":                    intPolicy("Minimum number of lower case characters"),
+		"upper_case":                    intPolicy("Minimum number of upper case characters"),
+		"special_chars":                 intPolicy("Minimum number of special characters"),
+		"password_history":              intPolicy("Number of previous passwords that may not be reused"),
+		"hash_iterations":               intPolicy("Number of hashing iterations"),
+		"force_expired_password_change": intPolicy("Number of days after which the password must be changed"),
+		"not_username": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"not_email": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"hash_algorithm": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"regex_pattern": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		// Policies without a dedicated attribute, e.g. custom policy providers, keyed by policy ID
+		"other_policies": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+	}
+}
+
+// Returns nil if the block is not set, and an empty policy if the block is empty.
+func getPasswordPolicy(d *schema.ResourceData) *keycloak.PasswordPolicy {
+	policies := d.Get("password_policy").([]interface{})
+	if len(policies) == 0 {
+		return nil
+	}
+
+	p := keycloak.PasswordPolicy{Other: getOptionalStringMap(d, "password_policy.0.other_policies")}
+	if policies[0] == nil {
+		return &p
+	}
+
+	policy := policies[0].(map[string]interface{})
+	p.Length = policy["length"].(int)
+	p.MaxLength = policy["max_length"].(int)
+	p.Digits = policy["digits"].(int)
+	p.LowerCase = policy["lower_case"].(int)
+	p.UpperCase = policy["upper_case"].(int)
+	p.SpecialChars = policy["special_chars"].(int)
+	p.PasswordHistory = policy["password_history"].(int)
+	p.HashIterations = policy["hash_iterations"].(int)
+	p.ForceExpiredPasswordChange = policy["force_expired_password_change"].(int)
+	p.NotUsername = policy["not_username"].(bool)
+	p.NotEmail = policy["not_email"].(bool)
+	p.HashAlgorithm = policy["hash_algorithm"].(string)
+	p.RegexPattern = policy["regex_pattern"].(string)
+
+	return &p
+}
+
+// An empty policy is kept as an empty block if it was configured as one, so that it doesn't show up as a change.
+func setPasswordPolicy(d *schema.ResourceData, p *keycloak.PasswordPolicy) {
+	if p.String() == "" && len(d.Get("password_policy").([]interface{})) == 0 {
+		d.Set("password_policy", nil)
+		return
+	}
+
+	d.Set("password_policy", []interface{}{
+		map[string]interface{}{
+			"length":                        p.Length,
+			"max_length":                    p.MaxLength,
+			"digits":                        p.Digits,
+			"lower_case":                    p.LowerCase,
+			"upper_case":                    p.UpperCase,
+			"special_chars":                 p.SpecialChars,
+			"password_history":              p.PasswordHistory,
+			"hash_iterations":               p.HashIterations,
+			"force_expired_password_change": p.ForceExpiredPasswordChange,
+			"not_username":                  p.NotUsername,
+			"not_email":                     p.NotEmail,
+			"hash_algorithm":                p.HashAlgorithm,
+			"regex_pattern":                 p.RegexPattern,
+			"other_policies":                p.Other,
+		},
+	})
+}
+
+func otpPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "totp",
+			ValidateFunc: validation.StringInSlice([]string{"totp", "hotp"}, false),
+		},
+		"algorithm": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "HmacSHA1",
+			ValidateFunc: validation.StringInSlice([]string{"HmacSHA1", "HmacSHA256", "HmacSHA512"}, false),
+		},
+		"digits": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      6,
+			ValidateFunc: validation.IntBetween(6, 8),
+		},
+		// Only used by time-based OTP, in seconds
+		"period": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  30,
+		},
+		"look_ahead_window": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  1,
+		},
+		// Only used by counter-based OTP
+		"initial_counter": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		// The authenticator applications that support the policy, as determined by Keycloak
+		"supported_applications": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func getOtpPolicy(d *schema.ResourceData, r *keycloak.Realm) {
+	policies := d.Get("otp_policy").([]interface{})
+	if len(policies) == 0 || policies[0] == nil {
+		return
+	}
+
+	policy := policies[0].(map[string]interface{})
+	digits := policy["digits"].(int)
+	period := policy["period"].(int)
+	lookAheadWindow := policy["look_ahead_window"].(int)
+	initialCounter := policy["initial_counter"].(int)
+
+	r.OtpPolicyType = policy["type"].(string)
+	r.OtpPolicyAlgorithm = policy["algorithm"].(string)
+	r.OtpPolicyDigits = &digits
+	r.OtpPolicyPeriod = &period
+	r.OtpPolicyLookAheadWindow = &lookAheadWindow
+	r.OtpPolicyInitialCounter = &initialCounter
+}
+
+func setOtpPolicy(d *schema.ResourceData, r *keycloak.Realm) {
+	if r.OtpPolicyType == "" {
+		return
+	}
+
+	policy := map[string]interface{}{
+		"type":                   r.OtpPolicyType,
+		"algorithm":              r.OtpPolicyAlgorithm,
+		"supported_applications": r.OtpSupportedApplications,
+	}
+	for key, value := range map[string]*int{
+		"digits":            r.OtpPolicyDigits,
+		"period":            r.OtpPolicyPeriod,
+		"look_ahead_window": r.OtpPolicyLookAheadWindow,
+		"initial_counter":   r.OtpPolicyInitialCounter,
+	} {
+		if value != nil {
+			policy[key] = *value
+		}
+	}
+
+	d.Set("otp_policy", []interface{}{policy})
+}
+
+// Keycloak uses "not specified" to leave the choice to the browser or authenticator.
+const webAuthnNotSpecified = "not specified"
+
+func webAuthnPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"relying_party_entity_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "keycloak",
+		},
+		// Defaults to the hostname of Keycloak if empty
+		"relying_party_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"signature_algorithms": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"ES256", "ES384", "ES512", "RS256", "RS384", "RS512", "RS1", "EdDSA"}, false),
+			},
+		},
+		"attestation_conveyance_preference": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      webAuthnNotSpecified,
+			ValidateFunc: validation.StringInSlice([]string{webAuthnNotSpecified, "none", "indirect", "direct"}, false),
+		},
+		"authenticator_attachment": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      webAuthnNotSpecified,
+			ValidateFunc: validation.StringInSlice([]string{webAuthnNotSpecified, "platform", "cross-platform"}, false),
+		},
+		"require_resident_key": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      webAuthnNotSpecified,
+			ValidateFunc: validation.StringInSlice([]string{webAuthnNotSpecified, "Yes", "No"}, false),
+		},
+		"user_verification_requirement": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      webAuthnNotSpecified,
+			ValidateFunc: validation.StringInSlice([]string{webAuthnNotSpecified, "required", "preferred", "discouraged"}, false),
+		},
+		// In seconds, 0 uses the browser's default
+		"create_timeout": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 31536),
+		},
+		"avoid_same_authenticator_register": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"acceptable_aaguids": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+// Returns the configured policy, or the policy from the state if the block is omitted. Nil is only returned for new
+// realms without a configured policy, which makes Keycloak use its defaults.
+func getWebAuthnPolicy(d *schema.ResourceData, key string) *keycloak.WebAuthnPolicy {
+	policies := d.Get(key).([]interface{})
+	if len(policies) == 0 || policies[0] == nil {
+		return nil
+	}
+
+	policy := policies[0].(map[string]interface{})
+	rpId := policy["relying_party_id"].(string)
+	createTimeout := policy["create_timeout"].(int)
+	avoidSameAuthenticatorRegister := policy["avoid_same_authenticator_register"].(bool)
+
+	p := keycloak.WebAuthnPolicy{
+		RpEntityName:                    policy["relying_party_entity_name"].(string),
+		RpId:                            &rpId,
+		AttestationConveyancePreference: policy["attestation_conveyance_preference"].(string),
+		AuthenticatorAttachment:         policy["authenticator_attachment"].(string),
+		RequireResidentKey:              policy["require_resident_key"].(string),
+		UserVerificationRequirement:     policy["user_verification_requirement"].(string),
+		CreateTimeout:                   &createTimeout,
+		AvoidSameAuthenticatorRegister:  &avoidSameAuthenticatorRegister,
+		AcceptableAaguids:               getMandatoryStringList(d, key+".0.acceptable_aaguids"),
+	}
+
+	// Keycloak uses its default algorithms if none are sent, which only happens for new realms as the algorithms
+	// are otherwise taken from the state.
+	if algorithms := getMandatoryStringList(d, key+".0.signature_algorithms"); len(algorithms) > 0 {
+		p.SignatureAlgorithms = algorithms
+	}
+
+	return &p
+}
+
+func setWebAuthnPolicy(d *schema.ResourceData, key string, p keycloak.WebAuthnPolicy) {
+	if p.RpEntityName == "" {
+		return
+	}
+
+	policy := map[string]interface{}{
+		"relying_party_entity_name":         p.RpEntityName,
+		"signature_algorithms":              p.SignatureAlgorithms,
+		"attestation_conveyance_preference": p.AttestationConveyancePreference,
+		"authenticator_attachment":          p.AuthenticatorAttachment,
+		"require_resident_key":              p.RequireResidentKey,
+		"user_verification_requirement":     p.UserVerificationRequirement,
+		"acceptable_aaguids":                p.AcceptableAaguids,
+	}
+	if p.RpId != nil {
+		policy["relying_party_id"] = *p.RpId
+	}
+	if p.CreateTimeout != nil {
+		policy["create_timeout"] = *p.CreateTimeout
+	}
+	if p.AvoidSameAuthenticatorRegister != nil {
+		policy["avoid_same_authenticator_register"] = *p.AvoidSameAuthenticatorRegister
+	}
+
+	d.Set(key, []interface{}{policy})
+}
+
+func smtpServerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		// Defaults to the standard port of the protocol if unset
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntBetween(1, 65535),
+		},
+		"from": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"from_display_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"reply_to": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"reply_to_display_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"envelope_from": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ssl": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"starttls": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		// Credentials for the SMTP server, no authentication is used if this is not set
+		"auth": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"username": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					// Keycloak never returns the password, so changes made outside of Terraform are not detected.
+					"password": {
+						Type:      schema.TypeString,
+						Required:  true,
+						Sensitive: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// An empty SMTP configuration is returned if the block is not set, which removes the realm's SMTP server.
+func getSmtpServer(d *schema.ResourceData) *keycloak.SmtpServer {
+	smtp := keycloak.SmtpServer{}
+
+	servers := d.Get("smtp_server").([]interface{})
+	if len(servers) == 0 || servers[0] == nil {
+		return &smtp
+	}
+
+	server := servers[0].(map[string]interface{})
+	smtp.Host = server["host"].(string)
+	smtp.From = server["from"].(string)
+	smtp.FromDisplayName = server["from_display_name"].(string)
+	smtp.ReplyTo = server["reply_to"].(string)
+	smtp.ReplyToDisplayName = server["reply_to_display_name"].(string)
+	smtp.EnvelopeFrom = server["envelope_from"].(string)
+	smtp.Ssl = strconv.FormatBool(server["ssl"].(bool))
+	smtp.StartTls = strconv.FormatBool(server["starttls"].(bool))
+
+	if port := server["port"].(int); port != 0 {
+		smtp.Port = strconv.Itoa(port)
+	}
+
+	smtp.Auth = "false"
+	if auth := server["auth"].([]interface{}); len(auth) > 0 && auth[0] != nil {
+		credentials := auth[0].(map[string]interface{})
+		smtp.Auth = "true"
+		smtp.User = credentials["username"].(string)
+		smtp.Password = credentials["password"].(string)
+	}
+
+	return &smtp
+}
+
+func setSmtpServer(d *schema.ResourceData, smtp *keycloak.SmtpServer) {
+	if smtp == nil || smtp.Host == "" {
+		d.Set("smtp_server", nil)
+		return
+	}
+
+	server := map[string]interface{}{
+		"host":                  smtp.Host,
+		"from":                  smtp.From,
+		"from_display_name":     smtp.FromDisplayName,
+		"reply_to":              smtp.ReplyTo,
+		"reply_to_display_name": smtp.ReplyToDisplayName,
+		"envelope_from":         smtp.EnvelopeFrom,
+		"ssl":                   parseConfigBool(smtp.Ssl),
+		"starttls":              parseConfigBool(smtp.StartTls),
+	}
+
+	if port, err := strconv.Atoi(smtp.Port); err == nil {
+		server["port"] = port
+	}
+
+	// The password is kept from the state, as Keycloak only returns asterisks.
+	if parseConfigBool(smtp.Auth) {
+		server["auth"] = []interface{}{
+			map[string]interface{}{
+				"username": smtp.User,
+				"password": d.Get("smtp_server.0.auth.0.password").(string),
+			},
+		}
+	}
+
+	d.Set("smtp_server", []interface{}{server})
+}
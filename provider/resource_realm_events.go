@@ -0,0 +1,134 @@
+// This file provides a Terraform resource for the event configuration of a realm. There is exactly one
+// configuration per realm, so the resource is identified by the realm name.
+// The event configuration is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_realmeventsconfigrepresentation
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmEvents() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceRealmEventsRead),
+		Create: schema.CreateFunc(resourceRealmEventsCreate),
+		Update: schema.UpdateFunc(resourceRealmEventsUpdate),
+		Delete: schema.DeleteFunc(resourceRealmEventsDelete),
+
+		// Event configurations are importable by realm name.
+		Importer: &schema.ResourceImporter{
+			State: importRealmEventsHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"events_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// In seconds, events are kept forever if this is 0.
+			"events_expiration": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+			},
+			// Types of the login events that are stored, e.g. 'LOGIN_ERROR'. All types are stored if this is empty.
+			"enabled_event_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"admin_events_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Whether the representations of changed resources are included in admin events
+			"admin_events_details_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// IDs of the event listener providers that receive all events. Keycloak's default 'jboss-logging'
+			// listener is removed unless it is listed.
+			"events_listeners": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func importRealmEventsHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRealmEventsRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	config, err := apiClient.GetRealmEventsConfig(d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("realm", d.Id())
+	d.Set("events_enabled", config.EventsEnabled)
+	d.Set("events_expiration", config.EventsExpiration)
+	d.Set("enabled_event_types", config.EnabledEventTypes)
+	d.Set("admin_events_enabled", config.AdminEventsEnabled)
+	d.Set("admin_events_details_enabled", config.AdminEventsDetailsEnabled)
+	d.Set("events_listeners", config.EventsListeners)
+
+	return nil
+}
+
+func resourceRealmEventsCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(realm(d))
+	return resourceRealmEventsUpdate(d, m)
+}
+
+func resourceRealmEventsUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	config := keycloak.RealmEventsConfig{
+		EventsEnabled:             d.Get("events_enabled").(bool),
+		EventsExpiration:          d.Get("events_expiration").(int),
+		AdminEventsEnabled:        d.Get("admin_events_enabled").(bool),
+		AdminEventsDetailsEnabled: d.Get("admin_events_details_enabled").(bool),
+		EnabledEventTypes:         getOptionalStringSet(d, "enabled_event_types"),
+		EventsListeners:           getOptionalStringSet(d, "events_listeners"),
+	}
+
+	err := apiClient.UpdateRealmEventsConfig(d.Id(), &config)
+	if err != nil {
+		return err
+	}
+
+	return resourceRealmEventsRead(d, m)
+}
+
+// Removing the resource disables event storage and restores Keycloak's default event listener.
+func resourceRealmEventsDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateRealmEventsConfig(d.Id(), &keycloak.RealmEventsConfig{
+		EventsListeners:   []string{"jboss-logging"},
+		EnabledEventTypes: []string{},
+	})
+	if keycloak.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func TestNewRealmEventsSendEmptyLists(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{"eventsEnabled":true,"eventsListeners":[],"enabledEventTypes":[]}`)
+	}))
+	defer server.Close()
+
+	apiClient, _ := keycloak.NewClientWithToken("token", server.URL, keycloak.WithBasePath(""))
+
+	d := schema.TestResourceDataRaw(t, resourceRealmEvents().Schema, map[string]interface{}{
+		"realm":            "test",
+		"events_enabled":   true,
+		"events_listeners": []interface{}{},
+	})
+	d.MarkNewResource()
+
+	if err := resourceRealmEventsCreate(d, apiClient); err != nil {
+		t.Fatalf("Events config was not created: %s", err)
+	}
+
+	// Null would leave Keycloak's default listener and event types in place.
+	for _, key := range []string{"eventsListeners", "enabledEventTypes"} {
+		if list, ok := sent[key].([]interface{}); !ok || len(list) != 0 {
+			t.Errorf("Expected %s to be sent as an empty list, got %v", key, sent[key])
+		}
+	}
+}
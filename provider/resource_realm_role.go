@@ -0,0 +1,160 @@
+// This file provides a Terraform resource for Keycloak realm roles
+// The role resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_rolerepresentation
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRealmRole() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceRealmRoleRead),
+		Create: schema.CreateFunc(resourceRealmRoleCreate),
+		Update: schema.UpdateFunc(resourceRealmRoleUpdate),
+		Delete: schema.DeleteFunc(resourceRealmRoleDelete),
+
+		// Realm roles are importable by name, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importRealmRoleHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"attributes": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			// IDs of the realm and client roles that are part of this role.
+			"composite_roles": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+		},
+	}
+}
+
+func importRealmRoleHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, name, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := m.(*keycloak.KeycloakClient)
+	role, err := apiClient.GetRealmRoleByName(realm, name)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(role.Id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRealmRoleRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	role, err := apiClient.GetRealmRole(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("name", role.Name)
+	d.Set("description", role.Description)
+	d.Set("attributes", fromMapOfStringSlices(role.Attributes))
+
+	compositeRoleIds, err := apiClient.GetRealmRoleComposites(realm(d), d.Id())
+	if err != nil {
+		return err
+	}
+
+	d.Set("composite_roles", compositeRoleIds)
+	return nil
+}
+
+func resourceRealmRoleCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	created, err := apiClient.CreateRealmRole(realm(d), resourceDataToRealmRole(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	roleIds := getOptionalStringSet(d, "composite_roles")
+	if len(roleIds) > 0 {
+		err = apiClient.AddRolesToRealmRole(realm(d), d.Id(), roleIds)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceRealmRoleRead(d, m)
+}
+
+func resourceRealmRoleUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	err := apiClient.UpdateRealmRole(realm(d), resourceDataToRealmRole(d))
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("composite_roles") {
+		rolesToAdd, rolesToRemove := getSetChanges(d, "composite_roles")
+
+		if len(rolesToAdd) > 0 {
+			err = apiClient.AddRolesToRealmRole(realm(d), d.Id(), rolesToAdd)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(rolesToRemove) > 0 {
+			err = apiClient.RemoveRolesFromRealmRole(realm(d), d.Id(), rolesToRemove)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return resourceRealmRoleRead(d, m)
+}
+
+func resourceRealmRoleDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	return apiClient.DeleteRealmRole(realm(d), d.Id())
+}
+
+func resourceDataToRealmRole(d *schema.ResourceData) *keycloak.RoleRepresentation {
+	r := keycloak.RoleRepresentation{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Attributes:  toMapOfStringSlices(getOptionalStringMap(d, "attributes")),
+	}
+
+	if !d.IsNewResource() {
+		r.Id = d.Id()
+	}
+
+	return &r
+}
@@ -0,0 +1,175 @@
+// This file provides a Terraform resource for the required actions of a realm, e.g. 'TERMS_AND_CONDITIONS'
+// or 'webauthn-register'. Required action providers that are deployed to Keycloak but not yet registered in
+// the realm are registered on creation.
+// The required action resource is documented at http://www.keycloak.org/docs-api/4.0/rest-api/index.html#_requiredactionproviderrepresentation
+
+package provider
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func resourceRequiredAction() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceRequiredActionRead),
+		Create: schema.CreateFunc(resourceRequiredActionCreate),
+		Update: schema.UpdateFunc(resourceRequiredActionUpdate),
+		Delete: schema.DeleteFunc(resourceRequiredActionDelete),
+
+		// Required actions are importable by alias, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importRequiredActionHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The provider ID of the required action, e.g. 'UPDATE_PROFILE'
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Defaults to the name of the provider
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			// Whether the action is assigned to all new users
+			"default_action": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Required actions are performed in the order of their priority, lowest first.
+			"priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func importRequiredActionHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, ids, ok := splitRealmIds(d.Id(), 1)
+	if !ok {
+		return nil, fmt.Errorf("Import ID must be specified as '${realm}.${alias}'")
+	}
+
+	d.SetId(ids[0])
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRequiredActionRead(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	action, err := apiClient.GetRequiredAction(realm(d), d.Id())
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	d.Set("alias", action.Alias)
+	d.Set("name", action.Name)
+	d.Set("enabled", action.Enabled)
+	d.Set("default_action", action.DefaultAction)
+	d.Set("priority", action.Priority)
+
+	return nil
+}
+
+func resourceRequiredActionCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	alias := d.Get("alias").(string)
+
+	_, err := apiClient.GetRequiredAction(realm(d), alias)
+	if keycloak.IsNotFound(err) {
+		err = registerRequiredAction(apiClient, d, alias)
+	}
+	if err != nil {
+		return err
+	}
+
+	d.SetId(alias)
+
+	return resourceRequiredActionUpdate(d, m)
+}
+
+func registerRequiredAction(apiClient *keycloak.KeycloakClient, d *schema.ResourceData, alias string) error {
+	unregistered, err := apiClient.GetUnregisteredRequiredActions(realm(d))
+	if err != nil {
+		return err
+	}
+
+	for _, action := range unregistered {
+		if action.ProviderId == alias {
+			if name, present := d.GetOk("name"); present {
+				action.Name = name.(string)
+			}
+
+			log.Printf("[DEBUG] Registering required action %s in realm %s", alias, realm(d))
+			return apiClient.RegisterRequiredAction(realm(d), &action)
+		}
+	}
+
+	return fmt.Errorf("No required action provider with ID '%s' is deployed to Keycloak", alias)
+}
+
+func resourceRequiredActionUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	// The current action is read to keep the settings that are not managed here.
+	action, err := apiClient.GetRequiredAction(realm(d), d.Id())
+	if err != nil {
+		return err
+	}
+
+	if name, present := d.GetOk("name"); present {
+		action.Name = name.(string)
+	}
+	if priority, present := d.GetOk("priority"); present {
+		action.Priority = priority.(int)
+	}
+	action.Enabled = d.Get("enabled").(bool)
+	action.DefaultAction = d.Get("default_action").(bool)
+
+	err = apiClient.UpdateRequiredAction(realm(d), action)
+	if err != nil {
+		return err
+	}
+
+	return resourceRequiredActionRead(d, m)
+}
+
+// Most required actions are registered by Keycloak itself, so they are disabled instead of unregistered.
+func resourceRequiredActionDelete(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+
+	action, err := apiClient.GetRequiredAction(realm(d), d.Id())
+	if keycloak.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	action.Enabled = false
+	action.DefaultAction = false
+
+	return apiClient.UpdateRequiredAction(realm(d), action)
+}
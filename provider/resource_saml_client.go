@@ -0,0 +1,327 @@
+// This file provides a Terraform resource for Keycloak clients using the SAML protocol
+// The client resource is documented at http://www.keycloak.org/docs-api/3.1/rest-api/index.html#_clientrepresentation
+
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+const samlProtocol = "saml"
+
+// Most SAML settings are stored as client attributes. These map resource attributes to client attributes.
+var samlClientBoolAttributes = map[string]string{
+	"include_authn_statement":   "saml.authnstatement",
+	"sign_documents":            "saml.server.signature",
+	"sign_assertions":           "saml.assertion.signature",
+	"encrypt_assertions":        "saml.encrypt",
+	"client_signature_required": "saml.client.signature",
+	"force_post_binding":        "saml.force.post.binding",
+	"force_name_id_format":      "saml_force_name_id_format",
+}
+
+var samlClientStringAttributes = map[string]string{
+	"name_id_format":                      "saml_name_id_format",
+	"signature_algorithm":                 "saml.signature.algorithm",
+	"assertion_consumer_post_url":         "saml_assertion_consumer_url_post",
+	"assertion_consumer_redirect_url":     "saml_assertion_consumer_url_redirect",
+	"logout_service_post_binding_url":     "saml_single_logout_service_url_post",
+	"logout_service_redirect_binding_url": "saml_single_logout_service_url_redirect",
+	"idp_initiated_sso_url_name":          "saml_idp_initiated_sso_url_name",
+	"idp_initiated_sso_relay_state":       "saml_idp_initiated_sso_relay_state",
+}
+
+// Keycloak generates signing and encryption keys for new SAML clients, so these are only sent if configured.
+var samlClientKeyAttributes = map[string]string{
+	"signing_certificate":    "saml.signing.certificate",
+	"signing_private_key":    "saml.signing.private.key",
+	"encryption_certificate": "saml.encryption.certificate",
+}
+
+func resourceSamlClient() *schema.Resource {
+	return &schema.Resource{
+		// API methods
+		Read:   schema.ReadFunc(resourceSamlClientRead),
+		Create: schema.CreateFunc(resourceSamlClientCreate),
+		Update: schema.UpdateFunc(resourceSamlClientUpdate),
+		Delete: schema.DeleteFunc(resourceClientDelete),
+
+		// Keycloak clients are importable by ID, but the realm must also be provided by the user.
+		Importer: &schema.ResourceImporter{
+			State: importSamlClientHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The entity ID of the service provider
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"include_authn_statement": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"sign_documents": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"sign_assertions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"encrypt_assertions": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"client_signature_required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"force_post_binding": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"front_channel_logout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"force_name_id_format": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"name_id_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "username",
+				ValidateFunc: validation.StringInSlice([]string{"username", "email", "transient", "persistent"}, false),
+			},
+			"signature_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RSA_SHA256",
+				ValidateFunc: validation.StringInSlice([]string{"RSA_SHA1", "RSA_SHA256", "RSA_SHA512", "DSA_SHA1"}, false),
+			},
+			"root_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"base_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"master_saml_processing_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"valid_redirect_uris": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"assertion_consumer_post_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"assertion_consumer_redirect_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logout_service_post_binding_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"logout_service_redirect_binding_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Enables IdP initiated SSO at '${realm}/protocol/saml/clients/${idp_initiated_sso_url_name}'
+			"idp_initiated_sso_url_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"idp_initiated_sso_relay_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			// Base64 encoded DER certificates and keys. PEM headers and line breaks are ignored when comparing.
+			"signing_certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressPemFormattingDiff,
+			},
+			"signing_private_key": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressPemFormattingDiff,
+			},
+			"encryption_certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressPemFormattingDiff,
+			},
+		},
+	}
+}
+
+func importSamlClientHelper(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	realm, id, err := splitRealmId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+	d.Set("realm", realm)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSamlClientRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*keycloak.KeycloakClient)
+
+	client, err := c.GetClient(d.Id(), realm(d))
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	samlClientToResourceData(client, d)
+	return nil
+}
+
+func resourceSamlClientCreate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	client := resourceDataToSamlClient(d)
+
+	created, err := apiClient.CreateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+
+	return resourceSamlClientRead(d, m)
+}
+
+func resourceSamlClientUpdate(d *schema.ResourceData, m interface{}) error {
+	apiClient := m.(*keycloak.KeycloakClient)
+	client := resourceDataToSamlClient(d)
+
+	err := apiClient.UpdateClient(&client, realm(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceSamlClientRead(d, m)
+}
+
+func resourceDataToSamlClient(d *schema.ResourceData) keycloak.Client {
+	c := keycloak.Client{
+		ClientId:           d.Get("client_id").(string),
+		Name:               d.Get("name").(string),
+		Description:        d.Get("description").(string),
+		Enabled:            d.Get("enabled").(bool),
+		Protocol:           samlProtocol,
+		RedirectUris:       getOptionalStringList(d, "valid_redirect_uris"),
+		WebOrigins:         []string{},
+		RootUrl:            d.Get("root_url").(string),
+		BaseUrl:            d.Get("base_url").(string),
+		AdminUrl:           d.Get("master_saml_processing_url").(string),
+		FrontchannelLogout: getBoolPointer(d, "front_channel_logout"),
+		Attributes:         map[string]string{},
+	}
+
+	for key, attribute := range samlClientBoolAttributes {
+		c.Attributes[attribute] = strconv.FormatBool(d.Get(key).(bool))
+	}
+
+	for key, attribute := range samlClientStringAttributes {
+		c.Attributes[attribute] = d.Get(key).(string)
+	}
+
+	for key, attribute := range samlClientKeyAttributes {
+		if value := d.Get(key).(string); value != "" {
+			c.Attributes[attribute] = stripPemFormatting(value)
+		}
+	}
+
+	if !d.IsNewResource() {
+		c.Id = d.Id()
+	}
+
+	return c
+}
+
+func samlClientToResourceData(c *keycloak.Client, d *schema.ResourceData) {
+	d.Set("client_id", c.ClientId)
+	d.Set("name", c.Name)
+	d.Set("description", c.Description)
+	d.Set("enabled", c.Enabled)
+	d.Set("valid_redirect_uris", c.RedirectUris)
+	d.Set("root_url", c.RootUrl)
+	d.Set("base_url", c.BaseUrl)
+	d.Set("master_saml_processing_url", c.AdminUrl)
+	setOptionalBool(d, "front_channel_logout", c.FrontchannelLogout)
+
+	for key, attribute := range samlClientBoolAttributes {
+		d.Set(key, parseConfigBool(c.Attributes[attribute]))
+	}
+
+	for key, attribute := range samlClientStringAttributes {
+		d.Set(key, c.Attributes[attribute])
+	}
+
+	for key, attribute := range samlClientKeyAttributes {
+		d.Set(key, c.Attributes[attribute])
+	}
+}
+
+// Keycloak stores certificates and keys as plain base64 without PEM headers or line breaks.
+func stripPemFormatting(value string) string {
+	lines := []string{}
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "-----") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func suppressPemFormattingDiff(k, old, new string, d *schema.ResourceData) bool {
+	return stripPemFormatting(old) == stripPemFormatting(new)
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Identity providers that implement SAML 2.0. Keycloak acts as the service provider towards them.
+func resourceSamlIdentityProvider() *schema.Resource {
+	return identityProviderResource(identityProviderType{
+		providerId: "saml",
+		schema: map[string]*schema.Schema{
+			// The entity ID that Keycloak uses as service provider, defaults to the realm's URL.
+			"entity_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"single_sign_on_service_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"single_logout_service_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name_id_policy_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified",
+			},
+			// Where the user ID is taken from, either the subject's name ID or an attribute of the assertion
+			"principal_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SUBJECT",
+				ValidateFunc: validation.StringInSlice([]string{"SUBJECT", "ATTRIBUTE", "FRIENDLY_ATTRIBUTE"}, false),
+			},
+			"principal_attribute": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"post_binding_authn_request": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"post_binding_response": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"post_binding_logout": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"backchannel_supported": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"force_authn": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"want_authn_requests_signed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"want_assertions_signed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"want_assertions_encrypted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"signature_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RSA_SHA256",
+				ValidateFunc: validation.StringInSlice([]string{"RSA_SHA1", "RSA_SHA256", "RSA_SHA512", "DSA_SHA1"}, false),
+			},
+			"validate_signature": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Base64 encoded DER certificates that signatures are validated with, separated by commas
+			"signing_certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressPemCertificatesDiff,
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["entityId"] = d.Get("entity_id").(string)
+			config["singleSignOnServiceUrl"] = d.Get("single_sign_on_service_url").(string)
+			config["singleLogoutServiceUrl"] = d.Get("single_logout_service_url").(string)
+			config["nameIDPolicyFormat"] = d.Get("name_id_policy_format").(string)
+			config["principalType"] = d.Get("principal_type").(string)
+			config["principalAttribute"] = d.Get("principal_attribute").(string)
+			config["postBindingAuthnRequest"] = strconv.FormatBool(d.Get("post_binding_authn_request").(bool))
+			config["postBindingResponse"] = strconv.FormatBool(d.Get("post_binding_response").(bool))
+			config["postBindingLogout"] = strconv.FormatBool(d.Get("post_binding_logout").(bool))
+			config["backchannelSupported"] = strconv.FormatBool(d.Get("backchannel_supported").(bool))
+			config["forceAuthn"] = strconv.FormatBool(d.Get("force_authn").(bool))
+			config["wantAuthnRequestsSigned"] = strconv.FormatBool(d.Get("want_authn_requests_signed").(bool))
+			config["wantAssertionsSigned"] = strconv.FormatBool(d.Get("want_assertions_signed").(bool))
+			config["wantAssertionsEncrypted"] = strconv.FormatBool(d.Get("want_assertions_encrypted").(bool))
+			config["signatureAlgorithm"] = d.Get("signature_algorithm").(string)
+			config["validateSignature"] = strconv.FormatBool(d.Get("validate_signature").(bool))
+			config["signingCertificate"] = stripPemCertificates(d.Get("signing_certificate").(string))
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("entity_id", config["entityId"])
+			d.Set("single_sign_on_service_url", config["singleSignOnServiceUrl"])
+			d.Set("single_logout_service_url", config["singleLogoutServiceUrl"])
+			d.Set("name_id_policy_format", config["nameIDPolicyFormat"])
+			d.Set("principal_type", config["principalType"])
+			d.Set("principal_attribute", config["principalAttribute"])
+			d.Set("post_binding_authn_request", parseConfigBool(config["postBindingAuthnRequest"]))
+			d.Set("post_binding_response", parseConfigBool(config["postBindingResponse"]))
+			d.Set("post_binding_logout", parseConfigBool(config["postBindingLogout"]))
+			d.Set("backchannel_supported", parseConfigBool(config["backchannelSupported"]))
+			d.Set("force_authn", parseConfigBool(config["forceAuthn"]))
+			d.Set("want_authn_requests_signed", parseConfigBool(config["wantAuthnRequestsSigned"]))
+			d.Set("want_assertions_signed", parseConfigBool(config["wantAssertionsSigned"]))
+			d.Set("want_assertions_encrypted", parseConfigBool(config["wantAssertionsEncrypted"]))
+			d.Set("signature_algorithm", config["signatureAlgorithm"])
+			d.Set("validate_signature", parseConfigBool(config["validateSignature"]))
+			d.Set("signing_certificate", config["signingCertificate"])
+		},
+	})
+}
+
+// Identity providers can have several signing certificates, e.g. while they roll over their keys, which
+// Keycloak stores separated by commas. Each PEM block is stripped separately.
+func stripPemCertificates(value string) string {
+	certificates := []string{}
+	lines := []string{}
+
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "-----BEGIN"):
+			lines = []string{}
+		case strings.HasPrefix(line, "-----END"):
+			certificates = append(certificates, strings.Join(lines, ""))
+			lines = []string{}
+		case line != "":
+			lines = append(lines, line)
+		}
+	}
+
+	// Certificates without PEM headers, e.g. as returned by Keycloak
+	if len(lines) > 0 {
+		certificates = append(certificates, strings.Join(lines, ""))
+	}
+
+	return strings.Join(certificates, ",")
+}
+
+func suppressPemCertificatesDiff(k, old, new string, d *schema.ResourceData) bool {
+	return stripPemCertificates(old) == stripPemCertificates(new)
+}
@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestStripPemCertificates(t *testing.T) {
+	pem := "-----BEGIN CERTIFICATE-----\nMIIC\nAAAA\n-----END CERTIFICATE-----\n-----BEGIN CERTIFICATE-----\nMIID\nBBBB\n-----END CERTIFICATE-----\n"
+
+	if stripped := stripPemCertificates(pem); stripped != "MIICAAAA,MIIDBBBB" {
+		t.Errorf("Expected certificates to be separated, got %s", stripped)
+	}
+
+	if stripped := stripPemCertificates("MIICAAAA,MIIDBBBB"); stripped != "MIICAAAA,MIIDBBBB" {
+		t.Errorf("Expected stripped certificates to be unchanged, got %s", stripped)
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Adds the roles of a user as an attribute to SAML assertions.
+func resourceSamlRoleListProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       samlProtocol,
+		protocolMapper: "saml-role-list-mapper",
+		schema: mergeSchemas(samlAttributeSchema(), map[string]*schema.Schema{
+			// Put all roles into a single attribute value instead of one value per role
+			"single_role_attribute": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return samlAttributeToConfig(d, map[string]string{
+				"single": strconv.FormatBool(d.Get("single_role_attribute").(bool)),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("single_role_attribute", parseConfigBool(config["single"]))
+			samlAttributeFromConfig(config, d)
+		},
+	})
+}
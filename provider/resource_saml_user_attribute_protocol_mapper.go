@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Adds the value of a user attribute as an attribute to SAML assertions.
+func resourceSamlUserAttributeProtocolMapper() *schema.Resource {
+	return protocolMapperResource(protocolMapperType{
+		protocol:       samlProtocol,
+		protocolMapper: "saml-user-attribute-mapper",
+		schema: mergeSchemas(samlAttributeSchema(), map[string]*schema.Schema{
+			"user_attribute": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}),
+		toConfig: func(d *schema.ResourceData) map[string]string {
+			return samlAttributeToConfig(d, map[string]string{
+				"user.attribute": d.Get("user_attribute").(string),
+			})
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("user_attribute", config["user.attribute"])
+			samlAttributeFromConfig(config, d)
+		},
+	})
+}
+
+// SAML mappers that add an attribute to assertions share the settings for naming the attribute.
+func samlAttributeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"saml_attribute_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"friendly_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"saml_attribute_name_format": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Basic",
+			ValidateFunc: validation.StringInSlice([]string{"Basic", "URI Reference", "Unspecified"}, false),
+		},
+	}
+}
+
+func samlAttributeToConfig(d *schema.ResourceData, config map[string]string) map[string]string {
+	config["attribute.name"] = d.Get("saml_attribute_name").(string)
+	config["friendly.name"] = d.Get("friendly_name").(string)
+	config["attribute.nameformat"] = d.Get("saml_attribute_name_format").(string)
+	return config
+}
+
+func samlAttributeFromConfig(config map[string]string, d *schema.ResourceData) {
+	d.Set("saml_attribute_name", config["attribute.name"])
+	d.Set("friendly_name", config["friendly.name"])
+	d.Set("saml_attribute_name_format", config["attribute.nameformat"])
+}
@@ -86,7 +86,7 @@ func resourceUserRead(d *schema.ResourceData, m interface{}) error {
 
 	user, err := c.GetUser(d.Id(), realm(d))
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	userToResourceData(user, d)
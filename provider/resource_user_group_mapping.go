@@ -36,7 +36,7 @@ func resourceUserGroupMappingRead(d *schema.ResourceData, m interface{}) error {
 
 	ug, err := c.GetUsersInGroup(d.Id(), realm(d))
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 	userGroupMappingToResourceData(ug, d)
 
@@ -92,12 +92,12 @@ func resourceUserRoleMappingRead(d *schema.ResourceData, m interface{}) error {
 
 	roles, err := c.GetCompositeRolesForUser(userId, realm(d), clientId)
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	role, err := c.FindRoleForUser(roles, d.Id())
 	if err != nil {
-		return err
+		return handleNotFoundError(err, d)
 	}
 
 	userRoleMappingToResourceData(userId, role, d)
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Sets the username of imported users from a template, e.g. '${CLAIM.email}' or '${ALIAS}.${CLAIM.sub}'.
+func resourceUsernameTemplateImporterIdentityProviderMapper() *schema.Resource {
+	return identityProviderMapperResource(identityProviderMapperType{
+		identityProviderMapper: protocolIdentityProviderMapper("oidc-username-idp-mapper", "saml-username-idp-mapper"),
+		schema: map[string]*schema.Schema{
+			"template": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// 'LOCAL' sets the username of the Keycloak user, 'BROKER_ID' and 'BROKER_USERNAME' set the
+			// ID and username of the link to the identity provider.
+			"target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "LOCAL",
+				ValidateFunc: validation.StringInSlice([]string{"LOCAL", "BROKER_ID", "BROKER_USERNAME"}, false),
+			},
+		},
+		toConfig: func(d *schema.ResourceData, config map[string]string) {
+			config["template"] = d.Get("template").(string)
+			config["target"] = d.Get("target").(string)
+		},
+		fromConfig: func(config map[string]string, d *schema.ResourceData) {
+			d.Set("template", config["template"])
+			// Older Keycloak versions only support setting the local username and omit the target.
+			if target, present := config["target"]; present {
+				d.Set("target", target)
+			}
+		},
+	})
+}
@@ -0,0 +1,273 @@
+// This file provides the shared implementation of the resources that manage all roles assigned to a
+// group or user. Roles are referenced by name, client roles additionally by the internal ID of their client.
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+// The API methods for reading and changing the role mappings of one group or user.
+type roleMappingTarget struct {
+	get               func() (*keycloak.RoleMappings, error)
+	addRealmRoles     func(roles []keycloak.RoleRepresentation) error
+	removeRealmRoles  func(roles []keycloak.RoleRepresentation) error
+	addClientRoles    func(clientId string, roles []keycloak.RoleRepresentation) error
+	removeClientRoles func(clientId string, roles []keycloak.RoleRepresentation) error
+}
+
+func groupRoleMappingTarget(apiClient *keycloak.KeycloakClient, realm string, groupId string) roleMappingTarget {
+	return roleMappingTarget{
+		get: func() (*keycloak.RoleMappings, error) {
+			return apiClient.GetGroupRoleMappings(realm, groupId)
+		},
+		addRealmRoles: func(roles []keycloak.RoleRepresentation) error {
+			return apiClient.AddRealmRolesToGroup(realm, groupId, roles)
+		},
+		removeRealmRoles: func(roles []keycloak.RoleRepresentation) error {
+			return apiClient.RemoveRealmRolesFromGroup(realm, groupId, roles)
+		},
+		addClientRoles: func(clientId string, roles []keycloak.RoleRepresentation) error {
+			return apiClient.AddClientRolesToGroup(realm, groupId, clientId, roles)
+		},
+		removeClientRoles: func(clientId string, roles []keycloak.RoleRepresentation) error {
+			return apiClient.RemoveClientRolesFromGroup(realm, groupId, clientId, roles)
+		},
+	}
+}
+
+func userRoleMappingTarget(apiClient *keycloak.KeycloakClient, realm string, userId string) roleMappingTarget {
+	return roleMappingTarget{
+		get: func() (*keycloak.RoleMappings, error) {
+			return apiClient.GetUserRoleMappings(realm, userId)
+		},
+		addRealmRoles: func(roles []keycloak.RoleRepresentation) error {
+			return apiClient.AddRealmRolesToUser(realm, userId, roles)
+		},
+		removeRealmRoles: func(roles []keycloak.RoleRepresentation) error {
+			return apiClient.RemoveRealmRolesFromUser(realm, userId, roles)
+		},
+		addClientRoles: func(clientId string, roles []keycloak.RoleRepresentation) error {
+			return apiClient.AddClientRolesToUser(realm, userId, clientId, roles)
+		},
+		removeClientRoles: func(clientId string, roles []keycloak.RoleRepresentation) error {
+			return apiClient.RemoveClientRolesFromUser(realm, userId, clientId, roles)
+		},
+	}
+}
+
+func roleMappingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Names of the assigned realm roles
+		"realm_roles": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+			Set:      schema.HashString,
+		},
+		"client_roles": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					// The internal ID of the client, i.e. the 'id' attribute of keycloak_client
+					"client_id": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					// Names of the assigned client roles
+					"roles": {
+						Type:     schema.TypeSet,
+						Required: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+						Set:      schema.HashString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Roles that are assigned by Keycloak itself rather than through Terraform.
+type unmanagedRoles struct {
+	realmRoles []string
+
+	// Names of client roles keyed by the internal ID of their client
+	clientRoles map[string][]string
+}
+
+// Reads all assigned roles, so that roles assigned outside of Terraform show up as changes. Roles that are
+// assigned by Keycloak itself can be passed as unmanaged roles, these are only read if they are configured.
+func readRoleMappings(target roleMappingTarget, d *schema.ResourceData, unmanaged unmanagedRoles) error {
+	mappings, err := target.get()
+	if err != nil {
+		return handleNotFoundError(err, d)
+	}
+
+	realmRoles := managedRoles(roleNames(mappings.RealmMappings), unmanaged.realmRoles, getOptionalStringSet(d, "realm_roles"))
+
+	configuredClientRoles := getClientRoles(d.Get("client_roles"))
+	clientRoles := []interface{}{}
+	for _, clientMappings := range mappings.ClientMappings {
+		roles := managedRoles(roleNames(clientMappings.Mappings), unmanaged.clientRoles[clientMappings.Id], configuredClientRoles[clientMappings.Id])
+		if len(roles) == 0 {
+			continue
+		}
+
+		clientRoles = append(clientRoles, map[string]interface{}{
+			"client_id": clientMappings.Id,
+			"roles":     roles,
+		})
+	}
+
+	d.Set("realm_roles", realmRoles)
+	d.Set("client_roles", clientRoles)
+
+	return nil
+}
+
+// Assigns and unassigns roles according to the changes between the state and the configuration.
+func updateRoleMappings(apiClient *keycloak.KeycloakClient, target roleMappingTarget, d *schema.ResourceData) error {
+	realmRolesToAdd, realmRolesToRemove := getSetChanges(d, "realm_roles")
+
+	oldClientRoles, newClientRoles := d.GetChange("client_roles")
+	clientRolesToAdd := diffClientRoles(getClientRoles(newClientRoles), getClientRoles(oldClientRoles))
+	clientRolesToRemove := diffClientRoles(getClientRoles(oldClientRoles), getClientRoles(newClientRoles))
+
+	return applyRoleMappingChanges(apiClient, realm(d), target, realmRolesToAdd, realmRolesToRemove, clientRolesToAdd, clientRolesToRemove)
+}
+
+// Unassigns all roles that are managed by the resource.
+func deleteRoleMappings(apiClient *keycloak.KeycloakClient, target roleMappingTarget, d *schema.ResourceData) error {
+	realmRoles := getOptionalStringSet(d, "realm_roles")
+	clientRoles := getClientRoles(d.Get("client_roles"))
+
+	return applyRoleMappingChanges(apiClient, realm(d), target, nil, realmRoles, map[string][]string{}, clientRoles)
+}
+
+func applyRoleMappingChanges(apiClient *keycloak.KeycloakClient, realm string, target roleMappingTarget,
+	realmRolesToAdd []string, realmRolesToRemove []string,
+	clientRolesToAdd map[string][]string, clientRolesToRemove map[string][]string) error {
+
+	// Roles are removed by ID, which is taken from the current assignments because
+	// the roles themselves may already have been deleted.
+	if len(realmRolesToRemove) > 0 || len(clientRolesToRemove) > 0 {
+		current, err := target.get()
+		if err != nil {
+			return err
+		}
+
+		roles := filterRolesByName(current.RealmMappings, realmRolesToRemove)
+		if len(roles) > 0 {
+			err = target.removeRealmRoles(roles)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, clientMappings := range current.ClientMappings {
+			roles := filterRolesByName(clientMappings.Mappings, clientRolesToRemove[clientMappings.Id])
+			if len(roles) > 0 {
+				err = target.removeClientRoles(clientMappings.Id, roles)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(realmRolesToAdd) > 0 {
+		roles := []keycloak.RoleRepresentation{}
+		for _, name := range realmRolesToAdd {
+			role, err := apiClient.GetRealmRoleByName(realm, name)
+			if err != nil {
+				return err
+			}
+			roles = append(roles, *role)
+		}
+
+		err := target.addRealmRoles(roles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for clientId, names := range clientRolesToAdd {
+		roles := []keycloak.RoleRepresentation{}
+		for _, name := range names {
+			role, err := apiClient.GetClientRole(clientId, realm, name)
+			if err != nil {
+				return err
+			}
+			roles = append(roles, *role)
+		}
+
+		err := target.addClientRoles(clientId, roles)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Converts the client_roles set into a map of client IDs to role names.
+func getClientRoles(raw interface{}) map[string][]string {
+	clientRoles := map[string][]string{}
+
+	for _, rawClientRoles := range raw.(*schema.Set).List() {
+		entry := rawClientRoles.(map[string]interface{})
+		clientId := entry["client_id"].(string)
+
+		for _, role := range entry["roles"].(*schema.Set).List() {
+			clientRoles[clientId] = append(clientRoles[clientId], role.(string))
+		}
+	}
+
+	return clientRoles
+}
+
+// Returns the client roles in a that are not in b.
+func diffClientRoles(a map[string][]string, b map[string][]string) map[string][]string {
+	diff := map[string][]string{}
+
+	for clientId, roles := range a {
+		for _, role := range roles {
+			if !contains(b[clientId], role) {
+				diff[clientId] = append(diff[clientId], role)
+			}
+		}
+	}
+
+	return diff
+}
+
+func roleNames(roles []keycloak.RoleRepresentation) []string {
+	names := []string{}
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+// Returns the assigned roles without the unmanaged roles that are not configured.
+func managedRoles(assigned []string, unmanaged []string, configured []string) []string {
+	managed := []string{}
+	for _, role := range assigned {
+		if !contains(unmanaged, role) || contains(configured, role) {
+			managed = append(managed, role)
+		}
+	}
+	return managed
+}
+
+func filterRolesByName(roles []keycloak.RoleRepresentation, names []string) []keycloak.RoleRepresentation {
+	filtered := []keycloak.RoleRepresentation{}
+	for _, role := range roles {
+		if contains(names, role.Name) {
+			filtered = append(filtered, role)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
+)
+
+func TestRealmDefaultRolesAreNotManaged(t *testing.T) {
+	unmanaged := realmDefaultRoles(&keycloak.Realm{Realm: "Jenkins", DefaultRoles: []string{"offline_access", "uma_authorization"}})
+	assigned := []string{"default-roles-jenkins", "offline_access", "uma_authorization", "api-reader", "admin"}
+
+	// Default roles don't show up as changes, but other roles that were assigned outside of Terraform do.
+	managed := managedRoles(assigned, unmanaged, []string{"api-reader"})
+	if !containsSameElements(managed, []string{"api-reader", "admin"}) {
+		t.Errorf("Expected default roles to be left out, got %v", managed)
+	}
+
+	// Default roles that are configured are managed like any other role.
+	managed = managedRoles(assigned, unmanaged, []string{"api-reader", "offline_access"})
+	if !containsSameElements(managed, []string{"api-reader", "admin", "offline_access"}) {
+		t.Errorf("Expected configured default roles to be read, got %v", managed)
+	}
+}
+
+func TestClientDefaultRolesAreNotManaged(t *testing.T) {
+	target := roleMappingTarget{
+		get: func() (*keycloak.RoleMappings, error) {
+			return &keycloak.RoleMappings{
+				ClientMappings: map[string]keycloak.ClientRoleMappings{
+					"account": {
+						Id:       "account-id",
+						Client:   "account",
+						Mappings: []keycloak.RoleRepresentation{{Name: "manage-account"}, {Name: "view-profile"}},
+					},
+					"api": {
+						Id:       "api-id",
+						Client:   "api",
+						Mappings: []keycloak.RoleRepresentation{{Name: "reader"}, {Name: "default"}},
+					},
+				},
+			}, nil
+		},
+	}
+	unmanaged := unmanagedRoles{
+		clientRoles: clientDefaultRoles([]keycloak.Client{
+			{Id: "account-id", DefaultRoles: []string{"manage-account", "view-profile"}},
+			{Id: "api-id", DefaultRoles: []string{"default"}},
+			{Id: "other-id"},
+		}),
+	}
+
+	d := schema.TestResourceDataRaw(t, roleMappingSchema(), map[string]interface{}{
+		"client_roles": []interface{}{
+			map[string]interface{}{"client_id": "api-id", "roles": []interface{}{"reader"}},
+		},
+	})
+
+	if err := readRoleMappings(target, d, unmanaged); err != nil {
+		t.Fatal(err)
+	}
+
+	// Clients with only default roles are left out entirely.
+	clientRoles := getClientRoles(d.Get("client_roles"))
+	if len(clientRoles) != 1 || !containsSameElements(clientRoles["api-id"], []string{"reader"}) {
+		t.Errorf("Expected default client roles to be left out, got %v", clientRoles)
+	}
+}
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/tazjin/terraform-provider-keycloak/keycloak"
 )
 
 func realm(d *schema.ResourceData) string {
@@ -15,6 +17,18 @@ func clientId(d *schema.ResourceData) string {
 	return d.Get("client_id").(string)
 }
 
+// Resources that were deleted outside of Terraform are removed from the state when they are read,
+// so that Terraform plans to re-create them instead of failing.
+func handleNotFoundError(err error, d *schema.ResourceData) error {
+	if keycloak.IsNotFound(err) {
+		log.Printf("[WARN] Removing %s from state because it no longer exists in Keycloak", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return err
+}
+
 func containsSameElements(a []string, b []string) bool {
 	if len(a) != len(b) {
 		return false
@@ -45,6 +59,12 @@ func getOptionalBool(d *schema.ResourceData, key string) *bool {
 	return nil
 }
 
+// Returns a pointer to the value of a boolean attribute with a default, for API fields that are omitted when nil.
+func getBoolPointer(d *schema.ResourceData, key string) *bool {
+	b := d.Get(key).(bool)
+	return &b
+}
+
 func setOptionalBool(d *schema.ResourceData, key string, b *bool) {
 	if b != nil {
 		d.Set(key, *b)
@@ -83,7 +103,7 @@ func getStringSlice(d *schema.ResourceData, key string) []string {
 // This function is used when importing realm-specific resources. The realm must be specified by the user when
 // importing by using a `${realm}.${resource_id}` syntax.
 func splitRealmId(raw string) (string, string, error) {
-	split := strings.Split(raw, ".")
+	split := strings.SplitN(raw, ".", 2)
 
 	if len(split) != 2 {
 		return "", "", fmt.Errorf("Import ID must be specified as '${realm}.${resource_id}'")
@@ -92,6 +112,18 @@ func splitRealmId(raw string) (string, string, error) {
 	return split[0], split[1], nil
 }
 
+// Splits import IDs of the form `${realm}.${id_1}.(...).${id_n}` into the realm and the n IDs. The IDs are split off
+// from the end because they never contain dots, unlike realm names.
+func splitRealmIds(raw string, n int) (string, []string, bool) {
+	split := strings.Split(raw, ".")
+	if len(split) <= n {
+		return "", nil, false
+	}
+
+	realm := strings.Join(split[:len(split)-n], ".")
+	return realm, split[len(split)-n:], realm != ""
+}
+
 func getMandatoryStringList(d *schema.ResourceData, key string) []string {
 	stringList := []string{}
 
@@ -124,3 +156,20 @@ func getOptionalStringMap(d *schema.ResourceData, key string) map[string]string
 	return stringMap
 
 }
+
+// Returns the elements that were added to and removed from a string set, e.g. to update role assignments.
+func getSetChanges(d *schema.ResourceData, key string) ([]string, []string) {
+	o, n := d.GetChange(key)
+	oldSet := o.(*schema.Set)
+	newSet := n.(*schema.Set)
+
+	return setToStringSlice(newSet.Difference(oldSet)), setToStringSlice(oldSet.Difference(newSet))
+}
+
+func setToStringSlice(set *schema.Set) []string {
+	stringList := []string{}
+	for _, stringVal := range set.List() {
+		stringList = append(stringList, stringVal.(string))
+	}
+	return stringList
+}
@@ -0,0 +1,16 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestSplitRealmIdsWithDotsInRealm(t *testing.T) {
+	realm, ids, ok := splitRealmIds("my.company.acme.flow-id.execution-id", 2)
+	if !ok || realm != "my.company.acme" || ids[0] != "flow-id" || ids[1] != "execution-id" {
+		t.Errorf("Unexpected result: %s %v %v", realm, ids, ok)
+	}
+
+	if _, _, ok := splitRealmIds("flow-id.execution-id", 2); ok {
+		t.Errorf("Expected import ID without realm to be rejected")
+	}
+}